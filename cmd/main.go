@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"os"
 
 	"admin.com/admin-api/config"
 	"admin.com/admin-api/internal/app"
 	dbpostgres "admin.com/admin-api/internal/repository/postgres"
+	"admin.com/admin-api/migrations"
 	"admin.com/admin-api/pkg/logger"
 )
 
@@ -32,6 +34,25 @@ func main() {
 		}
 	}()
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(context.Background(), dbConn, os.Args[2:], os.Stdout); err != nil {
+			slog.Error(logger.MsgMigrationFailed, "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if appCfg.MigrateOnStart {
+		migrator, err := dbpostgres.NewMigrator(dbConn, migrations.FS)
+		if err == nil {
+			err = migrateUp(context.Background(), migrator)
+		}
+		if err != nil {
+			slog.Error(logger.MsgMigrationFailed, "error", err)
+			os.Exit(1)
+		}
+	}
+
 	httpHandler, err := app.NewHandler(appCfg, dbConn)
 	if err != nil {
 		slog.Error(logger.MsgServerFailed, "error", err)
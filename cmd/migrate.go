@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"text/tabwriter"
+
+	dbpostgres "admin.com/admin-api/internal/repository/postgres"
+	"admin.com/admin-api/migrations"
+	"admin.com/admin-api/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+const migrateUsage = "usage: migrate up | down [steps] | status"
+
+func runMigrate(ctx context.Context, dbConn *bun.DB, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	migrator, err := dbpostgres.NewMigrator(dbConn, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		return migrateUp(ctx, migrator)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				return fmt.Errorf("invalid steps %q: %s", args[1], migrateUsage)
+			}
+		}
+
+		rolledBack, err := migrator.Down(ctx, steps)
+		for _, migration := range rolledBack {
+			slog.Info(logger.MsgMigrationRolledBack, "version", migration.Version, "name", migration.Name)
+		}
+		return err
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		return writeMigrationStatus(out, statuses)
+	default:
+		return fmt.Errorf("unknown migrate command %q: %s", args[0], migrateUsage)
+	}
+}
+
+func migrateUp(ctx context.Context, migrator *dbpostgres.Migrator) error {
+	applied, err := migrator.Up(ctx)
+	for _, migration := range applied {
+		slog.Info(logger.MsgMigrationApplied, "version", migration.Version, "name", migration.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(applied) == 0 {
+		slog.Info(logger.MsgMigrationsUpToDate)
+	}
+
+	return nil
+}
+
+func writeMigrationStatus(out io.Writer, statuses []dbpostgres.MigrationStatus) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+
+	for _, status := range statuses {
+		state, appliedAt := "pending", "-"
+		if status.Applied {
+			state = "applied"
+			appliedAt = status.AppliedAt.UTC().Format("2006-01-02 15:04:05")
+		}
+		if status.Modified {
+			state = "modified"
+		}
+
+		fmt.Fprintf(w, "%03d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+
+	return w.Flush()
+}
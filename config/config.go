@@ -1,11 +1,15 @@
 package config
 
 import (
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"net"
+	"net/netip"
 	"net/url"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -31,14 +35,30 @@ func Load() (Config, error) {
 		return Config{}, err
 	}
 
+	environment := getEnvOrDefault("APP_ENV", defaultEnvironment)
+	if environment != EnvironmentDevelopment && environment != EnvironmentProduction {
+		return Config{}, fmt.Errorf("APP_ENV must be %q or %q", EnvironmentDevelopment, EnvironmentProduction)
+	}
 	serverAddress := getEnvOrDefault("SERVER_ADDRESS", defaultAddress)
 	sslMode := getEnvOrDefault("DATABASE_SSL_MODE", defaultDatabaseSSLMode)
+	migrateOnStart, err := getBoolEnvOrDefault("DATABASE_MIGRATE_ON_START", defaultMigrateOnStart)
+	if err != nil {
+		return Config{}, err
+	}
 	corsAllowOrigin := getEnvOrDefault("CORS_ALLOW_ORIGIN", defaultCORSAllowOrigin)
 	corsAllowMethods := getEnvOrDefault("CORS_ALLOW_METHODS", defaultCORSAllowMethods)
 	corsAllowHeaders := getEnvOrDefault("CORS_ALLOW_HEADERS", defaultCORSAllowHeaders)
+	corsAllowCredentials, err := getBoolEnvOrDefault("CORS_ALLOW_CREDENTIALS", defaultCORSCredentials)
+	if err != nil {
+		return Config{}, err
+	}
 	logLevel := getEnvOrDefault("LOG_LEVEL", defaultLogLevel)
 	logFormat := getEnvOrDefault("LOG_FORMAT", defaultLogFormat)
-	authJWTSecret := getEnvOrDefault("AUTH_JWT_SECRET", defaultAuthJWTSecret)
+	authJWTKeys, err := getJWTKeys()
+	if err != nil {
+		return Config{}, err
+	}
+	authJWTActiveKeyID := os.Getenv("AUTH_JWT_ACTIVE_KID")
 	authJWTIssuer := getEnvOrDefault("AUTH_JWT_ISSUER", defaultAuthJWTIssuer)
 	authJWTAudience := getEnvOrDefault("AUTH_JWT_AUDIENCE", defaultAuthJWTAudience)
 	accessTokenTTL, err := getDurationEnvOrDefault("AUTH_ACCESS_TOKEN_TTL", defaultAccessTokenTTL)
@@ -56,26 +76,153 @@ func Load() (Config, error) {
 		return Config{}, err
 	}
 	refreshSameSite := getEnvOrDefault("AUTH_REFRESH_COOKIE_SAMESITE", defaultRefreshSameSite)
+	denylistBackend := getEnvOrDefault("AUTH_ACCESS_TOKEN_DENYLIST", defaultDenylistBackend)
+	if denylistBackend != StoreBackendPostgres && denylistBackend != StoreBackendMemory {
+		return Config{}, fmt.Errorf("AUTH_ACCESS_TOKEN_DENYLIST must be %q or %q", StoreBackendPostgres, StoreBackendMemory)
+	}
+	loginAttemptStore := getEnvOrDefault("AUTH_LOGIN_ATTEMPT_STORE", defaultLoginStore)
+	if loginAttemptStore != StoreBackendPostgres && loginAttemptStore != StoreBackendMemory {
+		return Config{}, fmt.Errorf("AUTH_LOGIN_ATTEMPT_STORE must be %q or %q", StoreBackendPostgres, StoreBackendMemory)
+	}
+	loginMaxFailures, err := getPositiveIntEnvOrDefault("AUTH_LOGIN_MAX_FAILURES", defaultLoginMaxFailures)
+	if err != nil {
+		return Config{}, err
+	}
+	loginMaxIPFailures, err := getPositiveIntEnvOrDefault("AUTH_LOGIN_MAX_IP_FAILURES", defaultLoginIPFailures)
+	if err != nil {
+		return Config{}, err
+	}
+	loginFailureWindow, err := getDurationEnvOrDefault("AUTH_LOGIN_FAILURE_WINDOW", defaultLoginWindow)
+	if err != nil {
+		return Config{}, err
+	}
+	loginLockout, err := getDurationEnvOrDefault("AUTH_LOGIN_LOCKOUT", defaultLoginLockout)
+	if err != nil {
+		return Config{}, err
+	}
+	loginMaxLockout, err := getDurationEnvOrDefault("AUTH_LOGIN_MAX_LOCKOUT", defaultLoginMaxLockout)
+	if err != nil {
+		return Config{}, err
+	}
+	if loginMaxLockout < loginLockout {
+		return Config{}, errors.New("AUTH_LOGIN_MAX_LOCKOUT must not be shorter than AUTH_LOGIN_LOCKOUT")
+	}
+	rateLimitEnabled, err := getBoolEnvOrDefault("RATE_LIMIT_ENABLED", defaultRateLimitEnabled)
+	if err != nil {
+		return Config{}, err
+	}
+	rateLimitStore := getEnvOrDefault("RATE_LIMIT_STORE", defaultRateLimitStore)
+	if rateLimitStore != StoreBackendPostgres && rateLimitStore != StoreBackendMemory {
+		return Config{}, fmt.Errorf("RATE_LIMIT_STORE must be %q or %q", StoreBackendPostgres, StoreBackendMemory)
+	}
+	rateLimitAuth, err := getRateLimitEnvOrDefault("RATE_LIMIT_AUTH", defaultRateLimitAuth)
+	if err != nil {
+		return Config{}, err
+	}
+	rateLimitUsers, err := getRateLimitEnvOrDefault("RATE_LIMIT_USERS", defaultRateLimitUsers)
+	if err != nil {
+		return Config{}, err
+	}
+	publicURL := getEnvOrDefault("APP_PUBLIC_URL", defaultPublicURL)
+	mailBackend := getEnvOrDefault("MAIL_BACKEND", defaultMailBackend)
+	if mailBackend != MailBackendLog && mailBackend != MailBackendFile && mailBackend != MailBackendSMTP {
+		return Config{}, fmt.Errorf("MAIL_BACKEND must be %q, %q or %q", MailBackendLog, MailBackendFile, MailBackendSMTP)
+	}
+	mailFrom := getEnvOrDefault("MAIL_FROM", defaultMailFrom)
+	mailFileDir := getEnvOrDefault("MAIL_FILE_DIR", defaultMailFileDir)
+	smtpHost := os.Getenv("SMTP_HOST")
+	if mailBackend == MailBackendSMTP && smtpHost == "" {
+		return Config{}, errors.New("SMTP_HOST is required when MAIL_BACKEND is smtp")
+	}
+	smtpPort := getEnvOrDefault("SMTP_PORT", defaultSMTPPort)
+	smtpUsername := os.Getenv("SMTP_USERNAME")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	requireVerifiedEmail, err := getBoolEnvOrDefault("AUTH_REQUIRE_VERIFIED_EMAIL", defaultRequireVerified)
+	if err != nil {
+		return Config{}, err
+	}
+	emailVerificationTTL, err := getDurationEnvOrDefault("AUTH_EMAIL_VERIFICATION_TTL", defaultVerificationTTL)
+	if err != nil {
+		return Config{}, err
+	}
+	passwordResetTTL, err := getDurationEnvOrDefault("AUTH_PASSWORD_RESET_TTL", defaultPasswordResetTTL)
+	if err != nil {
+		return Config{}, err
+	}
+	invitationTTL, err := getDurationEnvOrDefault("AUTH_INVITATION_TTL", defaultInvitationTTL)
+	if err != nil {
+		return Config{}, err
+	}
+	mfaEncryptionKey, err := getEncryptionKeyEnvOrDefault("AUTH_MFA_ENCRYPTION_KEY", defaultMFAEncryptionKey)
+	if err != nil {
+		return Config{}, err
+	}
+	mfaIssuer := getEnvOrDefault("AUTH_MFA_ISSUER", defaultMFAIssuer)
+	mfaChallengeTTL, err := getDurationEnvOrDefault("AUTH_MFA_CHALLENGE_TTL", defaultMFAChallengeTTL)
+	if err != nil {
+		return Config{}, err
+	}
+	trustedProxies, err := getPrefixListEnv("TRUSTED_PROXIES")
+	if err != nil {
+		return Config{}, err
+	}
 	dsn := buildPostgresDSN(dbHost, dbPort, dbUser, dbPass, dbName, sslMode)
 
-	return Config{
-		ServerAddress:    serverAddress,
-		DatabaseDSN:      dsn,
-		CORSAllowOrigin:  corsAllowOrigin,
-		CORSAllowMethods: corsAllowMethods,
-		CORSAllowHeaders: corsAllowHeaders,
-		LogLevel:         logLevel,
-		LogFormat:        logFormat,
-		AuthJWTSecret:    authJWTSecret,
-		AuthJWTIssuer:    authJWTIssuer,
-		AuthJWTAudience:  authJWTAudience,
-		AccessTokenTTL:   accessTokenTTL,
-		RefreshTokenTTL:  refreshTokenTTL,
-		RefreshCookie:    refreshCookie,
-		RefreshPath:      refreshPath,
-		RefreshSecure:    refreshSecure,
-		RefreshSameSite:  refreshSameSite,
-	}, nil
+	cfg := Config{
+		Environment:          environment,
+		ServerAddress:        serverAddress,
+		DatabaseDSN:          dsn,
+		DatabaseSSLMode:      sslMode,
+		MigrateOnStart:       migrateOnStart,
+		CORSAllowOrigin:      corsAllowOrigin,
+		CORSAllowMethods:     corsAllowMethods,
+		CORSAllowHeaders:     corsAllowHeaders,
+		CORSAllowCredentials: corsAllowCredentials,
+		LogLevel:             logLevel,
+		LogFormat:            logFormat,
+		AuthJWTKeys:          authJWTKeys,
+		AuthJWTActiveKeyID:   authJWTActiveKeyID,
+		AuthJWTIssuer:        authJWTIssuer,
+		AuthJWTAudience:      authJWTAudience,
+		AccessTokenTTL:       accessTokenTTL,
+		RefreshTokenTTL:      refreshTokenTTL,
+		RefreshCookie:        refreshCookie,
+		RefreshPath:          refreshPath,
+		RefreshSecure:        refreshSecure,
+		RefreshSameSite:      refreshSameSite,
+		DenylistBackend:      denylistBackend,
+		LoginAttemptStore:    loginAttemptStore,
+		LoginMaxFailures:     loginMaxFailures,
+		LoginMaxIPFailures:   loginMaxIPFailures,
+		LoginFailureWindow:   loginFailureWindow,
+		LoginLockout:         loginLockout,
+		LoginMaxLockout:      loginMaxLockout,
+		RateLimitEnabled:     rateLimitEnabled,
+		RateLimitStore:       rateLimitStore,
+		RateLimitAuth:        rateLimitAuth,
+		RateLimitUsers:       rateLimitUsers,
+		PublicURL:            publicURL,
+		MailBackend:          mailBackend,
+		MailFrom:             mailFrom,
+		MailFileDir:          mailFileDir,
+		SMTPHost:             smtpHost,
+		SMTPPort:             smtpPort,
+		SMTPUsername:         smtpUsername,
+		SMTPPassword:         smtpPassword,
+		RequireVerifiedEmail: requireVerifiedEmail,
+		EmailVerificationTTL: emailVerificationTTL,
+		PasswordResetTTL:     passwordResetTTL,
+		InvitationTTL:        invitationTTL,
+		MFAEncryptionKey:     mfaEncryptionKey,
+		MFAIssuer:            mfaIssuer,
+		MFAChallengeTTL:      mfaChallengeTTL,
+		TrustedProxies:       trustedProxies,
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
 }
 
 func buildPostgresDSN(host string, port string, user string, pass string, dbName string, sslMode string) string {
@@ -93,6 +240,69 @@ func buildPostgresDSN(host string, port string, user string, pass string, dbName
 	return u.String()
 }
 
+// getJWTKeys reads the key ring from AUTH_JWT_KEYS, entries separated by ";"
+// and written as comma separated key=value pairs:
+//
+//	kid=2026-10,alg=ES256,file=/keys/2026-10.pem;kid=2026-04,alg=HS256,secret=old,retire_at=2026-11-01T00:00:00Z
+//
+// An entry without kid verifies tokens issued before the ring, which carry no
+// kid header. Without AUTH_JWT_KEYS the single key from AUTH_JWT_ALGORITHM, AUTH_JWT_SECRET and
+// AUTH_JWT_PRIVATE_KEY_FILE is used, exactly as before key rotation existed.
+func getJWTKeys() ([]JWTKeyConfig, error) {
+	value := strings.TrimSpace(os.Getenv("AUTH_JWT_KEYS"))
+	if value == "" {
+		return []JWTKeyConfig{{
+			Algorithm:      getEnvOrDefault("AUTH_JWT_ALGORITHM", defaultAuthJWTAlgorithm),
+			Secret:         getEnvOrDefault("AUTH_JWT_SECRET", defaultAuthJWTSecret),
+			PrivateKeyFile: os.Getenv("AUTH_JWT_PRIVATE_KEY_FILE"),
+		}}, nil
+	}
+
+	var keys []JWTKeyConfig
+	for index, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key := JWTKeyConfig{Algorithm: defaultAuthJWTAlgorithm}
+		for _, pair := range strings.Split(entry, ",") {
+			name, fieldValue, ok := strings.Cut(pair, "=")
+			if !ok {
+				return nil, fmt.Errorf("AUTH_JWT_KEYS entry %d has invalid field %q", index+1, pair)
+			}
+
+			fieldValue = strings.TrimSpace(fieldValue)
+			switch strings.TrimSpace(name) {
+			case "kid":
+				key.KeyID = fieldValue
+			case "alg":
+				key.Algorithm = fieldValue
+			case "secret":
+				key.Secret = fieldValue
+			case "file":
+				key.PrivateKeyFile = fieldValue
+			case "retire_at":
+				retireAt, err := time.Parse(time.RFC3339, fieldValue)
+				if err != nil {
+					return nil, fmt.Errorf("AUTH_JWT_KEYS entry %d has invalid retire_at: %w", index+1, err)
+				}
+				key.RetireAt = &retireAt
+			default:
+				return nil, fmt.Errorf("AUTH_JWT_KEYS entry %d has unknown field %q", index+1, name)
+			}
+		}
+
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("AUTH_JWT_KEYS has no keys")
+	}
+
+	return keys, nil
+}
+
 func getRequiredEnv(name string) (string, error) {
 	value := os.Getenv(name)
 	if value == "" {
@@ -128,6 +338,91 @@ func getDurationEnvOrDefault(name string, fallback time.Duration) (time.Duration
 	return duration, nil
 }
 
+func getPositiveIntEnvOrDefault(name string, fallback int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s has invalid integer value: %w", name, err)
+	}
+
+	if parsed <= 0 {
+		return 0, fmt.Errorf("%s must be greater than zero", name)
+	}
+
+	return parsed, nil
+}
+
+func getRateLimitEnvOrDefault(name string, fallback string) (RateLimitConfig, error) {
+	value := getEnvOrDefault(name, fallback)
+
+	limitValue, windowValue, ok := strings.Cut(value, "/")
+	if !ok {
+		return RateLimitConfig{}, fmt.Errorf("%s must look like <requests>/<duration>", name)
+	}
+
+	limit, err := strconv.Atoi(strings.TrimSpace(limitValue))
+	if err != nil || limit <= 0 {
+		return RateLimitConfig{}, fmt.Errorf("%s must allow a positive number of requests", name)
+	}
+
+	window, err := time.ParseDuration(strings.TrimSpace(windowValue))
+	if err != nil {
+		return RateLimitConfig{}, fmt.Errorf("%s has invalid duration: %w", name, err)
+	}
+	if window <= 0 {
+		return RateLimitConfig{}, fmt.Errorf("%s window must be greater than zero", name)
+	}
+
+	return RateLimitConfig{Limit: limit, Window: window}, nil
+}
+
+// getPrefixListEnv reads comma separated addresses or CIDR ranges; a plain
+// address is a range of one.
+func getPrefixListEnv(name string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, entry := range strings.Split(os.Getenv(name), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if !strings.Contains(entry, "/") {
+			addr, err := netip.ParseAddr(entry)
+			if err != nil {
+				return nil, fmt.Errorf("%s has invalid address %q", name, entry)
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%s has invalid range %q", name, entry)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	return prefixes, nil
+}
+
+// getEncryptionKeyEnvOrDefault reads a base64 encoded AES-256 key.
+func getEncryptionKeyEnvOrDefault(name string, fallback string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(getEnvOrDefault(name, fallback))
+	if err != nil {
+		return nil, fmt.Errorf("%s has invalid base64 value: %w", name, err)
+	}
+
+	if len(key) != mfaEncryptionKeyBytes {
+		return nil, fmt.Errorf("%s must decode to %d bytes", name, mfaEncryptionKeyBytes)
+	}
+
+	return key, nil
+}
+
 func getBoolEnvOrDefault(name string, fallback bool) (bool, error) {
 	value := os.Getenv(name)
 	if value == "" {
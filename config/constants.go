@@ -3,14 +3,18 @@ package config
 import "time"
 
 const (
+	defaultEnvironment      = EnvironmentDevelopment
 	defaultAddress          = ":9090"
 	defaultDatabaseSSLMode  = "disable"
+	defaultMigrateOnStart   = false
 	defaultCORSAllowOrigin  = "*"
 	defaultCORSAllowMethods = "GET, POST, PUT, DELETE, OPTIONS"
 	defaultCORSAllowHeaders = "Content-Type, Authorization"
+	defaultCORSCredentials  = false
 	defaultLogLevel         = "info"
 	defaultLogFormat        = "json"
 	defaultAuthJWTSecret    = "change-me-dev-secret"
+	defaultAuthJWTAlgorithm = "HS256"
 	defaultAuthJWTIssuer    = "admin-api"
 	defaultAuthJWTAudience  = "admin-api-client"
 	defaultAccessTokenTTL   = 15 * time.Minute
@@ -19,4 +23,31 @@ const (
 	defaultRefreshPath      = "/auth"
 	defaultRefreshSecure    = false
 	defaultRefreshSameSite  = "Lax"
+	defaultDenylistBackend  = StoreBackendPostgres
+	defaultLoginStore       = StoreBackendPostgres
+	defaultLoginMaxFailures = 5
+	defaultLoginIPFailures  = 20
+	defaultLoginWindow      = 15 * time.Minute
+	defaultLoginLockout     = time.Minute
+	defaultLoginMaxLockout  = time.Hour
+	defaultRateLimitEnabled = true
+	defaultRateLimitStore   = StoreBackendMemory
+	defaultRateLimitAuth    = "10/1m"
+	defaultRateLimitUsers   = "120/1m"
+	defaultPublicURL        = "http://localhost:3000"
+	defaultMailBackend      = MailBackendLog
+	defaultMailFrom         = "no-reply@admin-api.local"
+	defaultMailFileDir      = "tmp/mail"
+	defaultSMTPPort         = "587"
+	defaultRequireVerified  = false
+	defaultVerificationTTL  = 24 * time.Hour
+	defaultPasswordResetTTL = 30 * time.Minute
+	defaultInvitationTTL    = 72 * time.Hour
+	defaultMFAIssuer        = "admin-api"
+	defaultMFAChallengeTTL  = 5 * time.Minute
+	// defaultMFAEncryptionKey is base64 of "dev-only-mfa-encryption-key-32b!".
+	defaultMFAEncryptionKey = "ZGV2LW9ubHktbWZhLWVuY3J5cHRpb24ta2V5LTMyYiE="
+
+	minProductionJWTSecretBytes = 32
+	mfaEncryptionKeyBytes       = 32
 )
@@ -1,28 +1,97 @@
 package config
 
-import "time"
+import (
+	"net/netip"
+	"time"
+)
 
 type Config struct {
-	ServerAddress    string
-	DatabaseDSN      string
-	CORSAllowOrigin  string
-	CORSAllowMethods string
-	CORSAllowHeaders string
-	LogLevel         string
-	LogFormat        string
-	AuthJWTSecret    string
-	AuthJWTIssuer    string
-	AuthJWTAudience  string
-	AccessTokenTTL   time.Duration
-	RefreshTokenTTL  time.Duration
-	RefreshCookie    string
-	RefreshPath      string
-	RefreshSecure    bool
-	RefreshSameSite  string
+	Environment          string
+	ServerAddress        string
+	DatabaseDSN          string
+	DatabaseSSLMode      string
+	MigrateOnStart       bool
+	CORSAllowOrigin      string
+	CORSAllowMethods     string
+	CORSAllowHeaders     string
+	CORSAllowCredentials bool
+	LogLevel             string
+	LogFormat            string
+	AuthJWTKeys          []JWTKeyConfig
+	AuthJWTActiveKeyID   string
+	AuthJWTIssuer        string
+	AuthJWTAudience      string
+	AccessTokenTTL       time.Duration
+	RefreshTokenTTL      time.Duration
+	RefreshCookie        string
+	RefreshPath          string
+	RefreshSecure        bool
+	RefreshSameSite      string
+	DenylistBackend      string
+	LoginAttemptStore    string
+	LoginMaxFailures     int
+	LoginMaxIPFailures   int
+	LoginFailureWindow   time.Duration
+	LoginLockout         time.Duration
+	LoginMaxLockout      time.Duration
+	RateLimitEnabled     bool
+	RateLimitStore       string
+	RateLimitAuth        RateLimitConfig
+	RateLimitUsers       RateLimitConfig
+	PublicURL            string
+	MailBackend          string
+	MailFrom             string
+	MailFileDir          string
+	SMTPHost             string
+	SMTPPort             string
+	SMTPUsername         string
+	SMTPPassword         string
+	RequireVerifiedEmail bool
+	EmailVerificationTTL time.Duration
+	PasswordResetTTL     time.Duration
+	InvitationTTL        time.Duration
+	MFAEncryptionKey     []byte
+	MFAIssuer            string
+	MFAChallengeTTL      time.Duration
+	TrustedProxies       []netip.Prefix
 }
 
+// RateLimitConfig allows Limit requests per Window, written "10/1m".
+type RateLimitConfig struct {
+	Limit  int
+	Window time.Duration
+}
+
+// JWTKeyConfig is one entry of AUTH_JWT_KEYS. RetireAt is nil for keys that
+// keep verifying tokens until they are removed from the ring.
+type JWTKeyConfig struct {
+	KeyID          string
+	Algorithm      string
+	Secret         string
+	PrivateKeyFile string
+	RetireAt       *time.Time
+}
+
+const (
+	EnvironmentDevelopment = "development"
+	EnvironmentProduction  = "production"
+)
+
+const (
+	MailBackendLog  = "log"
+	MailBackendFile = "file"
+	MailBackendSMTP = "smtp"
+)
+
+// Backends for the state shared by API instances (denylist, login attempts).
+const (
+	StoreBackendPostgres = "postgres"
+	StoreBackendMemory   = "memory"
+)
+
 type CORSConfig struct {
-	AllowOrigin  string
-	AllowMethods string
-	AllowHeaders string
+	AllowOrigin      string
+	AllowMethods     string
+	AllowHeaders     string
+	AllowCredentials bool
 }
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// productionSSLModes are the libpq modes that never fall back to plain text.
+var productionSSLModes = []string{"require", "verify-ca", "verify-full"}
+
+func (c Config) IsProduction() bool {
+	return c.Environment == EnvironmentProduction
+}
+
+// Validate rejects settings that are only acceptable during development. Every
+// problem is reported at once so a deployment can be fixed in a single pass.
+func (c Config) Validate() error {
+	if !c.IsProduction() {
+		return nil
+	}
+
+	var problems []error
+	for _, key := range c.AuthJWTKeys {
+		if key.Algorithm != "" && key.Algorithm != defaultAuthJWTAlgorithm {
+			continue
+		}
+
+		name := "AUTH_JWT_SECRET"
+		if key.KeyID != "" {
+			name = fmt.Sprintf("AUTH_JWT_KEYS secret of %q", key.KeyID)
+		}
+
+		if key.Secret == defaultAuthJWTSecret {
+			problems = append(problems, fmt.Errorf("%s must not be the default development secret", name))
+			continue
+		}
+		if len(key.Secret) < minProductionJWTSecretBytes {
+			problems = append(problems, fmt.Errorf("%s must be at least %d bytes", name, minProductionJWTSecretBytes))
+		}
+	}
+
+	if base64.StdEncoding.EncodeToString(c.MFAEncryptionKey) == defaultMFAEncryptionKey {
+		problems = append(problems, errors.New("AUTH_MFA_ENCRYPTION_KEY must not be the default development key"))
+	}
+	if c.MailBackend == MailBackendFile {
+		problems = append(problems, errors.New("MAIL_BACKEND must not be file, it stores the tokens of every email"))
+	}
+	if c.RequireVerifiedEmail && c.MailBackend != MailBackendSMTP {
+		problems = append(problems, errors.New("AUTH_REQUIRE_VERIFIED_EMAIL needs MAIL_BACKEND=smtp, otherwise no user can verify their email"))
+	}
+	if !c.RefreshSecure {
+		problems = append(problems, errors.New("AUTH_REFRESH_COOKIE_SECURE must be true"))
+	}
+	if c.CORSAllowCredentials && strings.Contains(c.CORSAllowOrigin, "*") {
+		problems = append(problems, errors.New("CORS_ALLOW_ORIGIN must not be a wildcard when CORS_ALLOW_CREDENTIALS is true"))
+	}
+	if !slices.Contains(productionSSLModes, c.DatabaseSSLMode) {
+		problems = append(problems, fmt.Errorf("DATABASE_SSL_MODE must be one of %s", strings.Join(productionSSLModes, ", ")))
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("invalid %s configuration: %w", c.Environment, errors.Join(problems...))
+}
@@ -3,63 +3,153 @@ package app
 import (
 	"crypto/rand"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"time"
 
 	"admin.com/admin-api/config"
+	domainauth "admin.com/admin-api/internal/domain/auth"
+	domainmail "admin.com/admin-api/internal/domain/mail"
+	domainratelimit "admin.com/admin-api/internal/domain/ratelimit"
 	httpcookie "admin.com/admin-api/internal/http/cookie"
 	authhttp "admin.com/admin-api/internal/http/handler/auth"
+	rolehttp "admin.com/admin-api/internal/http/handler/role"
 	userhttp "admin.com/admin-api/internal/http/handler/user"
+	wellknownhttp "admin.com/admin-api/internal/http/handler/wellknown"
 	"admin.com/admin-api/internal/http/middleware"
+	"admin.com/admin-api/internal/mailer"
 	authrepo "admin.com/admin-api/internal/repository/postgres/auth"
+	ratelimitrepo "admin.com/admin-api/internal/repository/postgres/ratelimit"
+	rolerepo "admin.com/admin-api/internal/repository/postgres/role"
 	userrepo "admin.com/admin-api/internal/repository/postgres/user"
+	"admin.com/admin-api/internal/security/audit"
+	securitydenylist "admin.com/admin-api/internal/security/denylist"
+	securityloginattempt "admin.com/admin-api/internal/security/loginattempt"
+	securityratelimit "admin.com/admin-api/internal/security/ratelimit"
 	securitytoken "admin.com/admin-api/internal/security/token"
 	authapp "admin.com/admin-api/internal/usecase/auth"
+	roleapp "admin.com/admin-api/internal/usecase/role"
 	userapp "admin.com/admin-api/internal/usecase/user"
 	"admin.com/admin-api/pkg/crypto"
 	"github.com/uptrace/bun"
 )
 
 func NewHandler(appCfg config.Config, dbConn *bun.DB) (http.Handler, error) {
-	userStore := userrepo.NewUserRepository(dbConn)
-	userUseCase := userapp.NewUserUseCase(userStore, crypto.HashPassword)
-
 	authStore := authrepo.NewAuthRepository(dbConn)
 	jwtMgr, err := securitytoken.NewJWT(securitytoken.Config{
-		Secret:    appCfg.AuthJWTSecret,
-		Issuer:    appCfg.AuthJWTIssuer,
-		Audience:  appCfg.AuthJWTAudience,
-		AccessTTL: appCfg.AccessTokenTTL,
+		Keys:        jwtKeyConfigs(appCfg.AuthJWTKeys),
+		ActiveKeyID: appCfg.AuthJWTActiveKeyID,
+		Issuer:      appCfg.AuthJWTIssuer,
+		Audience:    appCfg.AuthJWTAudience,
+		AccessTTL:   appCfg.AccessTokenTTL,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("build jwt manager: %w", err)
 	}
 
+	var denylist domainauth.AccessTokenDenylist = authrepo.NewAccessTokenDenylist(dbConn, time.Now)
+	if appCfg.DenylistBackend == config.StoreBackendMemory {
+		denylist = securitydenylist.NewMemory(time.Now)
+	}
+
+	var loginAttempts domainauth.LoginAttemptStore = authrepo.NewLoginAttemptStore(dbConn)
+	if appCfg.LoginAttemptStore == config.StoreBackendMemory {
+		loginAttempts = securityloginattempt.NewMemory()
+	}
+
+	var mailSender domainmail.Mailer = mailer.NewLogMailer(slog.Default(), appCfg.MailFrom, appCfg.IsProduction())
+	switch appCfg.MailBackend {
+	case config.MailBackendFile:
+		fileMailer, err := mailer.NewFileMailer(appCfg.MailFileDir, appCfg.MailFrom)
+		if err != nil {
+			return nil, fmt.Errorf("build file mailer: %w", err)
+		}
+		mailSender = fileMailer
+	case config.MailBackendSMTP:
+		smtpMailer, err := mailer.NewSMTPMailer(appCfg.SMTPHost, appCfg.SMTPPort, appCfg.MailFrom, appCfg.SMTPUsername, appCfg.SMTPPassword)
+		if err != nil {
+			return nil, fmt.Errorf("build smtp mailer: %w", err)
+		}
+		mailSender = smtpMailer
+	}
+
+	mfaCipher, err := crypto.NewAESGCM(appCfg.MFAEncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("build mfa secret cipher: %w", err)
+	}
+
 	authUseCase := authapp.NewAuthUseCase(authStore, jwtMgr, appCfg.RefreshTokenTTL, authapp.Dependencies{
 		HashPassword:     crypto.HashPassword,
 		ComparePassword:  crypto.ComparePassword,
 		Now:              time.Now,
 		RefreshTokenRand: rand.Reader,
+		SecurityEvents:   audit.NewLogRecorder(slog.Default()),
+		Denylist:         denylist,
+		LoginAttempts:    loginAttempts,
+		LoginThrottle: domainauth.LoginThrottlePolicy{
+			MaxAccountFailures: appCfg.LoginMaxFailures,
+			MaxIPFailures:      appCfg.LoginMaxIPFailures,
+			Window:             appCfg.LoginFailureWindow,
+			BaseLockout:        appCfg.LoginLockout,
+			MaxLockout:         appCfg.LoginMaxLockout,
+		},
+		Mailer: mailSender,
+		AccountEmails: authapp.AccountEmailConfig{
+			PublicURL:            appCfg.PublicURL,
+			RequireVerifiedEmail: appCfg.RequireVerifiedEmail,
+			EmailVerificationTTL: appCfg.EmailVerificationTTL,
+			PasswordResetTTL:     appCfg.PasswordResetTTL,
+			InvitationTTL:        appCfg.InvitationTTL,
+		},
+		SecretCipher: mfaCipher,
+		MFA: authapp.MFAConfig{
+			Issuer:       appCfg.MFAIssuer,
+			ChallengeTTL: appCfg.MFAChallengeTTL,
+		},
+	})
+
+	userStore := userrepo.NewUserRepository(dbConn)
+	userUseCase := userapp.NewUserUseCase(userStore, userapp.Dependencies{
+		Inviter: authUseCase,
+		Now:     time.Now,
 	})
 
+	roleStore := rolerepo.NewRoleRepository(dbConn)
+	roleUseCase := roleapp.NewRoleUseCase(roleStore, userStore)
+
+	authenticator := middleware.NewAuthenticator(authUseCase)
+
 	mux := http.NewServeMux()
-	userhttp.NewUserHandler(mux, userUseCase)
+	userhttp.NewUserHandler(mux, userUseCase, authenticator)
+	rolehttp.NewRoleHandler(mux, roleUseCase, authenticator)
+	wellknownhttp.NewWellKnownHandler(mux, jwtMgr)
 	authhttp.NewAuthHandler(mux, authUseCase, httpcookie.CookieConfig{
 		Name:     appCfg.RefreshCookie,
 		Path:     appCfg.RefreshPath,
 		Secure:   appCfg.RefreshSecure,
 		SameSite: httpcookie.ParseSameSite(appCfg.RefreshSameSite),
-	})
+	}, authenticator)
 
 	corsCfg := config.CORSConfig{
-		AllowOrigin:  appCfg.CORSAllowOrigin,
-		AllowMethods: appCfg.CORSAllowMethods,
-		AllowHeaders: appCfg.CORSAllowHeaders,
+		AllowOrigin:      appCfg.CORSAllowOrigin,
+		AllowMethods:     appCfg.CORSAllowMethods,
+		AllowHeaders:     appCfg.CORSAllowHeaders,
+		AllowCredentials: appCfg.CORSAllowCredentials,
 	}
-	httpHandler := middleware.CORSMiddleware(mux, corsCfg)
+	var httpHandler http.Handler = mux
+	if appCfg.RateLimitEnabled {
+		var rateLimitStore domainratelimit.Store = ratelimitrepo.NewRateLimitStore(dbConn)
+		if appCfg.RateLimitStore == config.StoreBackendMemory {
+			rateLimitStore = securityratelimit.NewMemory()
+		}
+
+		httpHandler = middleware.NewRateLimiter(rateLimitStore, jwtMgr, rateLimitRules(appCfg)).Limit(httpHandler)
+	}
+	httpHandler = middleware.CORSMiddleware(httpHandler, corsCfg)
 	httpHandler = middleware.RecoveryMiddleware(httpHandler)
 	httpHandler = middleware.RequestLoggingMiddleware(httpHandler)
 	httpHandler = middleware.RequestIDMiddleware(httpHandler)
+	httpHandler = middleware.ClientIPMiddleware(httpHandler, appCfg.TrustedProxies)
 
 	return httpHandler, nil
 }
@@ -70,3 +160,47 @@ func NewServer(appCfg config.Config, httpHandler http.Handler) *http.Server {
 		Handler: httpHandler,
 	}
 }
+
+func jwtKeyConfigs(keys []config.JWTKeyConfig) []securitytoken.KeyConfig {
+	configs := make([]securitytoken.KeyConfig, 0, len(keys))
+	for _, key := range keys {
+		keyConfig := securitytoken.KeyConfig{
+			ID:             key.KeyID,
+			Algorithm:      key.Algorithm,
+			Secret:         key.Secret,
+			PrivateKeyFile: key.PrivateKeyFile,
+		}
+		if key.RetireAt != nil {
+			keyConfig.RetireAt = *key.RetireAt
+		}
+		configs = append(configs, keyConfig)
+	}
+
+	return configs
+}
+
+// rateLimitRules keeps the endpoints that accept credentials on the strict
+// limit and every /users route on the looser one.
+func rateLimitRules(appCfg config.Config) []middleware.RateLimitRule {
+	authPolicy := func(name string) domainratelimit.Policy {
+		return domainratelimit.Policy{Name: name, Limit: appCfg.RateLimitAuth.Limit, Window: appCfg.RateLimitAuth.Window}
+	}
+	usersPolicy := domainratelimit.Policy{Name: "users", Limit: appCfg.RateLimitUsers.Limit, Window: appCfg.RateLimitUsers.Window}
+
+	return []middleware.RateLimitRule{
+		{Pattern: "POST /auth/login", Policy: authPolicy("auth_login")},
+		{Pattern: "POST /auth/register", Policy: authPolicy("auth_register")},
+		{Pattern: "POST /auth/refresh", Policy: authPolicy("auth_refresh")},
+		{Pattern: "POST /auth/verify-email", Policy: authPolicy("auth_verify_email")},
+		{Pattern: "POST /auth/resend-verification", Policy: authPolicy("auth_resend_verification")},
+		{Pattern: "POST /auth/password/forgot", Policy: authPolicy("auth_password_forgot")},
+		{Pattern: "POST /auth/password/reset", Policy: authPolicy("auth_password_reset")},
+		{Pattern: "POST /auth/password/change", Policy: authPolicy("auth_password_change")},
+		{Pattern: "POST /auth/invitation/accept", Policy: authPolicy("auth_invitation_accept")},
+		{Pattern: "POST /auth/mfa/challenge", Policy: authPolicy("auth_mfa_challenge")},
+		{Pattern: "POST /auth/mfa/totp/setup", Policy: authPolicy("auth_mfa_setup")},
+		{Pattern: "POST /auth/mfa/totp/verify", Policy: authPolicy("auth_mfa_verify")},
+		{Pattern: "/users", Policy: usersPolicy},
+		{Pattern: "/users/", Policy: usersPolicy},
+	}
+}
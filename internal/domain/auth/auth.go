@@ -1,42 +1,76 @@
 package auth
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type AccessTokenSubject struct {
+	UserID      uuid.UUID
+	Roles       []string
+	Permissions []string
+}
+
 type AccessTokenClaims struct {
-	Subject   string
-	Audience  string
-	Issuer    string
-	IssuedAt  time.Time
-	ExpiresAt time.Time
-	TokenID   string
+	Subject     string
+	Audience    string
+	Issuer      string
+	IssuedAt    time.Time
+	ExpiresAt   time.Time
+	TokenID     string
+	Roles       []string
+	Permissions []string
 }
 
 type AccessTokenManager interface {
-	GenerateAccessToken(userID uuid.UUID) (string, time.Time, error)
+	GenerateAccessToken(subject AccessTokenSubject) (string, time.Time, error)
 	ParseAccessToken(token string) (*AccessTokenClaims, error)
 }
 
+const (
+	maxUserAgentLength   = 512
+	maxIPAddressLength   = 64
+	maxDeviceLabelLength = 100
+)
+
+// ClientMetadata describes where a session was opened. It is informational
+// only and never used for authorization decisions.
+type ClientMetadata struct {
+	UserAgent   string
+	IPAddress   string
+	DeviceLabel string
+}
+
+func NewClientMetadata(userAgent string, ipAddress string, deviceLabel string) ClientMetadata {
+	return ClientMetadata{
+		UserAgent:   truncate(strings.TrimSpace(userAgent), maxUserAgentLength),
+		IPAddress:   truncate(strings.TrimSpace(ipAddress), maxIPAddressLength),
+		DeviceLabel: truncate(strings.TrimSpace(deviceLabel), maxDeviceLabelLength),
+	}
+}
+
 type RefreshToken struct {
-	ID         uuid.UUID
-	UserID     uuid.UUID
-	FamilyID   uuid.UUID
-	TokenHash  string
-	ExpiresAt  time.Time
-	RevokedAt  *time.Time
-	LastUsedAt *time.Time
-	CreatedAt  time.Time
+	ID           uuid.UUID
+	UserID       uuid.UUID
+	FamilyID     uuid.UUID
+	TokenHash    string
+	ExpiresAt    time.Time
+	RevokedAt    *time.Time
+	LastUsedAt   *time.Time
+	ReplacedByID *uuid.UUID
+	Client       ClientMetadata
+	CreatedAt    time.Time
 }
 
-func NewRefreshToken(userID uuid.UUID, familyID uuid.UUID, tokenHash string, expiresAt time.Time) *RefreshToken {
+func NewRefreshToken(userID uuid.UUID, familyID uuid.UUID, tokenHash string, expiresAt time.Time, client ClientMetadata) *RefreshToken {
 	return &RefreshToken{
 		UserID:    userID,
 		FamilyID:  familyID,
 		TokenHash: tokenHash,
 		ExpiresAt: expiresAt.UTC(),
+		Client:    client,
 	}
 }
 
@@ -50,3 +84,18 @@ func (t *RefreshToken) IsActiveAt(now time.Time) bool {
 
 	return t.ExpiresAt.After(now.UTC())
 }
+
+// WasRotated reports whether the token was already exchanged for a newer one,
+// so presenting it again means someone else holds a copy.
+func (t *RefreshToken) WasRotated() bool {
+	return t != nil && t.ReplacedByID != nil
+}
+
+func truncate(value string, maxRunes int) string {
+	runes := []rune(value)
+	if len(runes) <= maxRunes {
+		return value
+	}
+
+	return string(runes[:maxRunes])
+}
@@ -0,0 +1,13 @@
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// AccessTokenDenylist revokes individual access tokens by jti before they
+// expire. Entries only need to live until the token's own expiry.
+type AccessTokenDenylist interface {
+	Deny(ctx context.Context, tokenID string, expiresAt time.Time) error
+	IsDenied(ctx context.Context, tokenID string) (bool, error)
+}
@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SecurityEventType string
+
+const (
+	SecurityEventRefreshTokenReused  SecurityEventType = "refresh_token_reused"
+	SecurityEventUserSessionsRevoked SecurityEventType = "user_sessions_revoked"
+	SecurityEventPasswordReset       SecurityEventType = "password_reset"
+	SecurityEventPasswordChanged     SecurityEventType = "password_changed"
+	SecurityEventInvitationAccepted  SecurityEventType = "invitation_accepted"
+	SecurityEventMFAEnabled          SecurityEventType = "mfa_enabled"
+	SecurityEventRecoveryCodeUsed    SecurityEventType = "mfa_recovery_code_used"
+	SecurityEventAccountEmailFailed  SecurityEventType = "account_email_failed"
+)
+
+type SecurityEvent struct {
+	Type       SecurityEventType
+	UserID     uuid.UUID
+	FamilyID   uuid.UUID
+	TokenID    uuid.UUID
+	OccurredAt time.Time
+	Attributes map[string]any
+}
+
+// SecurityEventRecorder must not fail the request that produced the event.
+type SecurityEventRecorder interface {
+	Record(ctx context.Context, event SecurityEvent)
+}
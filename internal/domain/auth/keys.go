@@ -0,0 +1,18 @@
+package auth
+
+// PublicKey is the verification half of an asymmetric access token signing
+// key, in JWK terms (RFC 7517).
+type PublicKey struct {
+	KeyID     string
+	KeyType   string
+	Algorithm string
+	Curve     string
+	N         string
+	E         string
+	X         string
+	Y         string
+}
+
+type PublicKeySource interface {
+	PublicKeys() []PublicKey
+}
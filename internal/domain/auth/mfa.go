@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"encoding/base32"
+	"io"
+	"strings"
+	"time"
+
+	"admin.com/admin-api/internal/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	RecoveryCodeCount = 10
+	recoveryCodeBytes = 10
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTPCredential holds the user's authenticator secret, encrypted with a
+// SecretCipher. It only protects logins once ConfirmedAt is set.
+type TOTPCredential struct {
+	UserID           uuid.UUID
+	SecretCiphertext string
+	ConfirmedAt      *time.Time
+	// LastUsedStep is the TOTP step of the last accepted code; codes of that
+	// step or earlier are rejected so an observed code cannot be replayed.
+	LastUsedStep int64
+	CreatedAt    time.Time
+}
+
+func (c *TOTPCredential) IsConfirmed() bool {
+	return c != nil && c.ConfirmedAt != nil
+}
+
+// SecretCipher encrypts the secrets that must be read back, unlike passwords
+// and tokens which are only stored as hashes.
+type SecretCipher interface {
+	Seal(plaintext []byte) (string, error)
+	Open(ciphertext string) ([]byte, error)
+}
+
+// GenerateRecoveryCodes returns codes formatted as xxxx-xxxx-xxxx-xxxx.
+func GenerateRecoveryCodes(random io.Reader) ([]string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	raw := make([]byte, recoveryCodeBytes)
+	for i := range codes {
+		if _, err := io.ReadFull(random, raw); err != nil {
+			return nil, domain.ErrInternalServerError
+		}
+
+		encoded := strings.ToLower(recoveryCodeEncoding.EncodeToString(raw))
+		codes[i] = encoded[0:4] + "-" + encoded[4:8] + "-" + encoded[8:12] + "-" + encoded[12:16]
+	}
+
+	return codes, nil
+}
+
+// HashRecoveryCode ignores case, spaces and dashes, which users tend to get
+// wrong when typing a code back.
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return HashRefreshToken(normalized)
+}
+
+func MFAThrottleKey(userID uuid.UUID) string {
+	return "mfa:" + userID.String()
+}
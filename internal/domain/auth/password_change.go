@@ -0,0 +1,20 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordChange replaces PreviousHash only if it is still the stored hash, so
+// two concurrent changes cannot both pass the current password check.
+type PasswordChange struct {
+	UserID       uuid.UUID
+	PreviousHash string
+	PasswordHash string
+	ChangedAt    time.Time
+	// RevokeOtherSessions revokes every refresh token family of the user but
+	// KeepFamilyID; a nil KeepFamilyID revokes them all.
+	RevokeOtherSessions bool
+	KeepFamilyID        uuid.UUID
+}
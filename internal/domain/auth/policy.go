@@ -22,6 +22,11 @@ type LoginData struct {
 	Password string
 }
 
+type PasswordChangeData struct {
+	CurrentPassword string
+	NewPassword     string
+}
+
 func NormalizeRegister(data RegisterData) (RegisterData, error) {
 	user, err := userdomain.NewUser(userdomain.UserProfile{
 		Name:     data.Name,
@@ -66,6 +71,23 @@ func NormalizeLogin(data LoginData) (LoginData, error) {
 	}, nil
 }
 
+func NormalizePasswordChange(data PasswordChangeData) (PasswordChangeData, error) {
+	currentPassword := domain.TrimPassword(data.CurrentPassword)
+	newPassword := domain.TrimPassword(data.NewPassword)
+	if currentPassword == "" || newPassword == "" {
+		return PasswordChangeData{}, domain.ErrBadRequest
+	}
+
+	if err := domain.ValidatePassword(newPassword); err != nil {
+		return PasswordChangeData{}, err
+	}
+
+	return PasswordChangeData{
+		CurrentPassword: currentPassword,
+		NewPassword:     newPassword,
+	}, nil
+}
+
 func HashRefreshToken(token string) string {
 	sum := sha256.Sum256([]byte(token))
 	return hex.EncodeToString(sum[:])
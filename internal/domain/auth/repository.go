@@ -12,8 +12,45 @@ type AuthRepository interface {
 	CreateUser(ctx context.Context, user *userdomain.User) error
 	GetUserByID(ctx context.Context, id uuid.UUID) (*userdomain.User, error)
 	GetUserByIdentity(ctx context.Context, identity string) (*userdomain.User, error)
+	GetUserRoleNames(ctx context.Context, userID uuid.UUID) ([]string, error)
+	GetUserPermissions(ctx context.Context, userID uuid.UUID) ([]string, error)
 	CreateRefreshToken(ctx context.Context, token *RefreshToken) error
 	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
 	RotateRefreshToken(ctx context.Context, currentTokenID uuid.UUID, nextToken *RefreshToken, usedAt time.Time) error
 	RevokeRefreshTokenByHash(ctx context.Context, tokenHash string, revokedAt time.Time) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID, revokedAt time.Time) (int, error)
+	GetActiveSessions(ctx context.Context, userID uuid.UUID, now time.Time) ([]Session, error)
+	RevokeUserSession(ctx context.Context, userID uuid.UUID, familyID uuid.UUID, revokedAt time.Time) error
+	RevokeUserSessions(ctx context.Context, userID uuid.UUID, revokedAt time.Time) (int, error)
+	RevokeUserAccess(ctx context.Context, userID uuid.UUID, revokedAt time.Time) (int, error)
+	// CreateUserToken also retires the unused tokens the user already has for
+	// the same purpose, so only the latest email works.
+	CreateUserToken(ctx context.Context, token *UserToken) error
+	// VerifyEmail consumes the token and marks the email verified in one
+	// transaction; unknown, used or expired tokens return domain.ErrNotFound.
+	VerifyEmail(ctx context.Context, tokenHash string, verifiedAt time.Time) (uuid.UUID, error)
+	// ChangePassword returns the number of refresh tokens it revoked, and
+	// ErrInvalidCurrentPassword when the stored hash is no longer PreviousHash.
+	ChangePassword(ctx context.Context, change PasswordChange) (int, error)
+	// SetPasswordWithToken consumes a password reset or invitation token,
+	// stores the new hash and revokes every refresh token and issued access
+	// token of the user in one transaction.
+	SetPasswordWithToken(ctx context.Context, purpose UserTokenPurpose, tokenHash string, passwordHash string, setAt time.Time) (uuid.UUID, int, error)
+	// GetActiveUserToken returns domain.ErrNotFound for unknown, used or
+	// expired tokens; ConsumeUserToken also marks the token used.
+	GetActiveUserToken(ctx context.Context, purpose UserTokenPurpose, tokenHash string, now time.Time) (*UserToken, error)
+	ConsumeUserToken(ctx context.Context, purpose UserTokenPurpose, tokenHash string, usedAt time.Time) (*UserToken, error)
+	GetTOTPCredential(ctx context.Context, userID uuid.UUID) (*TOTPCredential, error)
+	// SaveTOTPCredential replaces an unconfirmed credential and returns
+	// domain.ErrMFAAlreadyEnabled when the user has a confirmed one.
+	SaveTOTPCredential(ctx context.Context, credential *TOTPCredential) error
+	// ConfirmTOTPCredential enables the credential and replaces the user's
+	// recovery codes in one transaction.
+	ConfirmTOTPCredential(ctx context.Context, userID uuid.UUID, step int64, confirmedAt time.Time, recoveryCodeHashes []string) error
+	// UseTOTPStep returns domain.ErrNotFound when step is not newer than the
+	// last accepted one.
+	UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error
+	// UseRecoveryCode returns how many unused codes are left, and
+	// domain.ErrNotFound for unknown or used codes.
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, usedAt time.Time) (int, error)
 }
@@ -0,0 +1,17 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is a refresh token family seen through its currently active token.
+type Session struct {
+	FamilyID   uuid.UUID
+	UserID     uuid.UUID
+	StartedAt  time.Time
+	LastUsedAt time.Time
+	ExpiresAt  time.Time
+	Client     ClientMetadata
+}
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"admin.com/admin-api/internal/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultMaxAccountFailures = 5
+	defaultMaxIPFailures      = 20
+	defaultFailureWindow      = 15 * time.Minute
+	defaultBaseLockout        = time.Minute
+	defaultMaxLockout         = time.Hour
+)
+
+// LoginAttempt is the failure counter behind one throttle key, either an
+// account identity or a client IP address.
+type LoginAttempt struct {
+	Key             string
+	Failures        int
+	WindowStartedAt time.Time
+	Lockouts        int
+	LockedUntil     time.Time
+	UpdatedAt       time.Time
+}
+
+func (a LoginAttempt) IsLockedAt(now time.Time) bool {
+	return now.Before(a.LockedUntil)
+}
+
+// LoginAttemptStore keeps the counters. RecordFailure must count atomically:
+// failures restart at one when the window of the stored entry has passed, and
+// entries idle for longer than the policy retention are forgotten, lockouts
+// included.
+type LoginAttemptStore interface {
+	Get(ctx context.Context, key string) (LoginAttempt, error)
+	RecordFailure(ctx context.Context, key string, at time.Time, policy LoginThrottlePolicy) (LoginAttempt, error)
+	Lock(ctx context.Context, key string, at time.Time, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
+
+type LoginThrottlePolicy struct {
+	MaxAccountFailures int
+	MaxIPFailures      int
+	Window             time.Duration
+	BaseLockout        time.Duration
+	MaxLockout         time.Duration
+}
+
+func (p LoginThrottlePolicy) WithDefaults() LoginThrottlePolicy {
+	if p.MaxAccountFailures <= 0 {
+		p.MaxAccountFailures = defaultMaxAccountFailures
+	}
+	if p.MaxIPFailures <= 0 {
+		p.MaxIPFailures = defaultMaxIPFailures
+	}
+	if p.Window <= 0 {
+		p.Window = defaultFailureWindow
+	}
+	if p.BaseLockout <= 0 {
+		p.BaseLockout = defaultBaseLockout
+	}
+	if p.MaxLockout < p.BaseLockout {
+		p.MaxLockout = max(defaultMaxLockout, p.BaseLockout)
+	}
+
+	return p
+}
+
+// LockoutFor doubles the lock for every earlier lockout of the same key.
+func (p LoginThrottlePolicy) LockoutFor(previousLockouts int) time.Duration {
+	lockout := p.BaseLockout
+	for i := 0; i < previousLockouts && lockout < p.MaxLockout; i++ {
+		lockout *= 2
+	}
+
+	return min(lockout, p.MaxLockout)
+}
+
+// Retention is how long an idle key keeps its lockout history.
+func (p LoginThrottlePolicy) Retention() time.Duration {
+	return p.Window + p.MaxLockout
+}
+
+// AccountThrottleKey is shared by every identity of the account, so the
+// username and the email cannot be guessed against separately.
+func AccountThrottleKey(userID uuid.UUID) string {
+	return "account:" + userID.String()
+}
+
+// IdentityThrottleKey counts the attempts against identities that match no
+// account.
+func IdentityThrottleKey(identity string) string {
+	return "identity:" + identity
+}
+
+func IPThrottleKey(ipAddress string) string {
+	return "ip:" + ipAddress
+}
+
+// LoginLockedError is an ErrAccountLocked that also tells the client when to
+// retry.
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return domain.AccountLockedMessage
+}
+
+func (e *LoginLockedError) Unwrap() error {
+	return domain.ErrAccountLocked
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"time"
+
+	"admin.com/admin-api/internal/domain"
+	userdomain "admin.com/admin-api/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+type UserTokenPurpose string
+
+const (
+	UserTokenPurposeEmailVerification UserTokenPurpose = "email_verification"
+	UserTokenPurposePasswordReset     UserTokenPurpose = "password_reset"
+	UserTokenPurposeInvitation        UserTokenPurpose = "invitation"
+	UserTokenPurposeMFAChallenge      UserTokenPurpose = "mfa_challenge"
+)
+
+// UserToken is a single-use token mailed to a user. Only the hash of the
+// token is stored, like refresh tokens.
+type UserToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Purpose   UserTokenPurpose
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func NewUserToken(userID uuid.UUID, purpose UserTokenPurpose, tokenHash string, expiresAt time.Time) *UserToken {
+	return &UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt.UTC(),
+	}
+}
+
+func HashUserToken(token string) string {
+	return HashRefreshToken(token)
+}
+
+// GenerateUserToken returns the token to mail and the hash to store.
+func GenerateUserToken(random io.Reader) (string, string, error) {
+	raw := make([]byte, 48)
+	if _, err := io.ReadFull(random, raw); err != nil {
+		return "", "", domain.ErrInternalServerError
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, HashUserToken(token), nil
+}
+
+// Inviter mails users created by an administrator the link to choose their
+// first password. The account already exists when InviteUser runs, so its
+// failures are recorded instead of returned; the user can still reset the
+// password. ResendInvitation is asked for explicitly and returns them.
+type Inviter interface {
+	InviteUser(ctx context.Context, user *userdomain.User)
+	ResendInvitation(ctx context.Context, user *userdomain.User) error
+}
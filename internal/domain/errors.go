@@ -8,12 +8,34 @@ const (
 	ConflictMessage            = "conflict"
 	BadRequestMessage          = "bad request"
 	UnauthorizedMessage        = "unauthorized"
+	ForbiddenMessage           = "forbidden"
+	TooManyRequestsMessage     = "too many requests"
 
 	UsernameExistsMessage     = ConflictMessage
 	EmailExistsMessage        = ConflictMessage
 	InvalidCredentialsMessage = UnauthorizedMessage
 	InvalidEmailMessage       = BadRequestMessage
 	WeakPasswordMessage       = BadRequestMessage
+	RoleNameExistsMessage     = ConflictMessage
+	RoleInUseMessage          = ConflictMessage
+	BuiltInRoleMessage        = ConflictMessage
+	InvalidRoleNameMessage    = BadRequestMessage
+	InvalidQueryMessage       = BadRequestMessage
+
+	InvalidStatusTransitionMessage = ConflictMessage
+	UserNotDeletedMessage          = ConflictMessage
+	PasswordAlreadySetMessage      = ConflictMessage
+	AccountSuspendedMessage        = ForbiddenMessage
+	AccountLockedMessage           = TooManyRequestsMessage
+	AccountLockedByAdminMessage    = ForbiddenMessage
+	AccountPendingMessage          = ForbiddenMessage
+	RefreshTokenReusedMessage      = UnauthorizedMessage
+	InvalidUserTokenMessage        = BadRequestMessage
+	EmailNotVerifiedMessage        = ForbiddenMessage
+	InvalidCurrentPasswordMessage  = BadRequestMessage
+	PasswordReusedMessage          = BadRequestMessage
+	MFAAlreadyEnabledMessage       = ConflictMessage
+	InvalidMFACodeMessage          = BadRequestMessage
 )
 
 var (
@@ -22,9 +44,31 @@ var (
 	ErrConflict            = errors.New(ConflictMessage)
 	ErrBadRequest          = errors.New(BadRequestMessage)
 	ErrUnauthorized        = errors.New(UnauthorizedMessage)
+	ErrForbidden           = errors.New(ForbiddenMessage)
+	ErrTooManyRequests     = errors.New(TooManyRequestsMessage)
 	ErrUsernameExists      = errors.New(UsernameExistsMessage)
 	ErrEmailExists         = errors.New(EmailExistsMessage)
 	ErrInvalidCredentials  = errors.New(InvalidCredentialsMessage)
 	ErrInvalidEmail        = errors.New(InvalidEmailMessage)
 	ErrWeakPassword        = errors.New(WeakPasswordMessage)
+	ErrRoleNameExists      = errors.New(RoleNameExistsMessage)
+	ErrRoleInUse           = errors.New(RoleInUseMessage)
+	ErrBuiltInRole         = errors.New(BuiltInRoleMessage)
+	ErrInvalidRoleName     = errors.New(InvalidRoleNameMessage)
+	ErrInvalidQuery        = errors.New(InvalidQueryMessage)
+
+	ErrInvalidStatusTransition = errors.New(InvalidStatusTransitionMessage)
+	ErrUserNotDeleted          = errors.New(UserNotDeletedMessage)
+	ErrPasswordAlreadySet      = errors.New(PasswordAlreadySetMessage)
+	ErrAccountSuspended        = errors.New(AccountSuspendedMessage)
+	ErrAccountLocked           = errors.New(AccountLockedMessage)
+	ErrAccountLockedByAdmin    = errors.New(AccountLockedByAdminMessage)
+	ErrAccountPending          = errors.New(AccountPendingMessage)
+	ErrRefreshTokenReused      = errors.New(RefreshTokenReusedMessage)
+	ErrInvalidUserToken        = errors.New(InvalidUserTokenMessage)
+	ErrEmailNotVerified        = errors.New(EmailNotVerifiedMessage)
+	ErrInvalidCurrentPassword  = errors.New(InvalidCurrentPasswordMessage)
+	ErrPasswordReused          = errors.New(PasswordReusedMessage)
+	ErrMFAAlreadyEnabled       = errors.New(MFAAlreadyEnabledMessage)
+	ErrInvalidMFACode          = errors.New(InvalidMFACodeMessage)
 )
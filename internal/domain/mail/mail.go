@@ -0,0 +1,16 @@
+package mail
+
+import "context"
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers transactional emails. Bodies carry single-use tokens, so
+// anything that stores them (logs, files) is only fit for development; SMTP is
+// the backend that actually reaches users.
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Policy is a token bucket holding up to Limit requests that refills
+// completely over Window.
+type Policy struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+}
+
+func (p Policy) IsValid() bool {
+	return p.Name != "" && p.Limit > 0 && p.Window > 0
+}
+
+// refillInterval is the time one token takes to come back.
+func (p Policy) refillInterval() time.Duration {
+	return p.Window / time.Duration(p.Limit)
+}
+
+// Bucket is the stored state of one key: the tokens left at UpdatedAt.
+type Bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// FullAt is when the bucket is back to Limit tokens and can be forgotten.
+func (b Bucket) FullAt(policy Policy) time.Time {
+	missing := float64(policy.Limit) - b.Tokens
+	if missing <= 0 {
+		return b.UpdatedAt
+	}
+
+	return b.UpdatedAt.Add(time.Duration(missing * float64(policy.refillInterval())))
+}
+
+type Decision struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+	RetryAfter time.Duration
+}
+
+// Take refills the bucket for the time elapsed since it was stored and spends
+// one token when available. A zero bucket is a new, full one.
+func (p Policy) Take(bucket Bucket, now time.Time) (Bucket, Decision) {
+	tokens := float64(p.Limit)
+	if !bucket.UpdatedAt.IsZero() {
+		elapsed := max(now.Sub(bucket.UpdatedAt), 0)
+		tokens = min(bucket.Tokens+float64(elapsed)/float64(p.refillInterval()), float64(p.Limit))
+	}
+
+	decision := Decision{Limit: p.Limit}
+	if tokens >= 1 {
+		tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = time.Duration((1 - tokens) * float64(p.refillInterval()))
+	}
+
+	next := Bucket{Tokens: tokens, UpdatedAt: now}
+	decision.Remaining = int(tokens)
+	decision.ResetAfter = next.FullAt(p).Sub(now)
+
+	return next, decision
+}
+
+// Store keeps buckets per key. Take must read, refill and spend atomically so
+// concurrent requests cannot spend the same token twice.
+type Store interface {
+	Take(ctx context.Context, key string, policy Policy, now time.Time) (Decision, error)
+}
@@ -0,0 +1,17 @@
+package role
+
+import "slices"
+
+const (
+	PermissionUsersRead     = "users:read"
+	PermissionUsersWrite    = "users:write"
+	PermissionUsersPurge    = "users:purge"
+	PermissionUsersSessions = "users:sessions"
+	PermissionRolesRead     = "roles:read"
+	PermissionRolesWrite    = "roles:write"
+	PermissionRolesAssign   = "roles:assign"
+)
+
+func HasPermission(granted []string, permission string) bool {
+	return slices.Contains(granted, permission)
+}
@@ -0,0 +1,22 @@
+package role
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type RoleRepository interface {
+	GetRoles(ctx context.Context) ([]Role, error)
+	GetRoleByID(ctx context.Context, id uuid.UUID) (*Role, error)
+	CreateRole(ctx context.Context, role *Role) error
+	UpdateRole(ctx context.Context, role *Role) error
+	DeleteRole(ctx context.Context, id uuid.UUID) error
+	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]Role, error)
+	GetUserPermissions(ctx context.Context, userID uuid.UUID) ([]string, error)
+	AssignRole(ctx context.Context, userID uuid.UUID, roleID uuid.UUID) error
+	RevokeRole(ctx context.Context, userID uuid.UUID, roleID uuid.UUID) error
+	GetRolePermissions(ctx context.Context, roleID uuid.UUID) ([]string, error)
+	GrantPermission(ctx context.Context, roleID uuid.UUID, permission string) error
+	RevokePermission(ctx context.Context, roleID uuid.UUID, permission string) error
+}
@@ -0,0 +1,85 @@
+package role
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"admin.com/admin-api/internal/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	NameAdmin   = "admin"
+	NameManager = "manager"
+	NameViewer  = "viewer"
+
+	nameMinLength        = 2
+	nameMaxLength        = 50
+	descriptionMaxLength = 255
+)
+
+type Role struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type RoleDetails struct {
+	Name        string
+	Description string
+}
+
+func NewRole(details RoleDetails) (*Role, error) {
+	role := &Role{}
+	if err := role.SetDetails(details); err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+func (r *Role) SetDetails(details RoleDetails) error {
+	name := strings.TrimSpace(details.Name)
+	description := strings.TrimSpace(details.Description)
+
+	nameLength := utf8.RuneCountInString(name)
+	if nameLength < nameMinLength || nameLength > nameMaxLength {
+		return domain.ErrInvalidRoleName
+	}
+	if utf8.RuneCountInString(description) > descriptionMaxLength {
+		return domain.ErrBadRequest
+	}
+
+	r.Name = name
+	r.Description = description
+	return nil
+}
+
+// IsBuiltIn reports the roles seeded by the migrations. Route permissions and
+// default grants refer to them by name, so they cannot be renamed or deleted.
+func (r *Role) IsBuiltIn() bool {
+	switch r.Name {
+	case NameAdmin, NameManager, NameViewer:
+		return true
+	default:
+		return false
+	}
+}
+
+// KeepsAllPermissions reports the admin role, which no permission can be
+// revoked from so that someone can always manage the others.
+func (r *Role) KeepsAllPermissions() bool {
+	return r.Name == NameAdmin
+}
+
+func Names(roles []Role) []string {
+	names := make([]string, len(roles))
+	for i := range roles {
+		names[i] = roles[i].Name
+	}
+
+	return names
+}
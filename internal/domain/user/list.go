@@ -0,0 +1,172 @@
+package user
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"admin.com/admin-api/internal/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+type SortField string
+
+const (
+	SortByCreatedAt SortField = "created_at"
+	SortByUpdatedAt SortField = "updated_at"
+	SortByUsername  SortField = "username"
+)
+
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+type ListParams struct {
+	Limit          int
+	Cursor         string
+	Sort           string
+	Order          string
+	EmailPrefix    string
+	UsernamePrefix string
+}
+
+type ListFilter struct {
+	EmailPrefix    string
+	UsernamePrefix string
+}
+
+type ListCursor struct {
+	SortField SortField `json:"s"`
+	SortOrder SortOrder `json:"o"`
+	Value     string    `json:"v"`
+	ID        uuid.UUID `json:"id"`
+}
+
+type ListQuery struct {
+	Filter    ListFilter
+	SortField SortField
+	SortOrder SortOrder
+	Limit     int
+	After     *ListCursor
+}
+
+func NewListQuery(params ListParams) (ListQuery, error) {
+	query := ListQuery{
+		Filter: ListFilter{
+			EmailPrefix:    strings.ToLower(strings.TrimSpace(params.EmailPrefix)),
+			UsernamePrefix: strings.ToLower(strings.TrimSpace(params.UsernamePrefix)),
+		},
+		SortField: SortByCreatedAt,
+		SortOrder: SortDesc,
+		Limit:     params.Limit,
+	}
+
+	if sort := strings.TrimSpace(params.Sort); sort != "" {
+		field := SortField(strings.ToLower(sort))
+		if !field.isValid() {
+			return ListQuery{}, domain.ErrInvalidQuery
+		}
+		query.SortField = field
+	}
+
+	if order := strings.TrimSpace(params.Order); order != "" {
+		sortOrder := SortOrder(strings.ToLower(order))
+		if sortOrder != SortAsc && sortOrder != SortDesc {
+			return ListQuery{}, domain.ErrInvalidQuery
+		}
+		query.SortOrder = sortOrder
+	}
+
+	switch {
+	case query.Limit == 0:
+		query.Limit = DefaultListLimit
+	case query.Limit < 0 || query.Limit > MaxListLimit:
+		return ListQuery{}, domain.ErrInvalidQuery
+	}
+
+	if cursor := strings.TrimSpace(params.Cursor); cursor != "" {
+		after, err := decodeListCursor(cursor)
+		if err != nil {
+			return ListQuery{}, err
+		}
+		if after.SortField != query.SortField || after.SortOrder != query.SortOrder {
+			return ListQuery{}, domain.ErrInvalidQuery
+		}
+		query.After = after
+	}
+
+	return query, nil
+}
+
+func (q ListQuery) CursorAfter(user *User) string {
+	cursor := ListCursor{
+		SortField: q.SortField,
+		SortOrder: q.SortOrder,
+		ID:        user.ID,
+	}
+
+	switch q.SortField {
+	case SortByUpdatedAt:
+		cursor.Value = user.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	case SortByUsername:
+		cursor.Value = user.Username
+	default:
+		cursor.Value = user.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	encoded, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+func (c ListCursor) TimeValue() (time.Time, error) {
+	value, err := time.Parse(time.RFC3339Nano, c.Value)
+	if err != nil {
+		return time.Time{}, domain.ErrInvalidQuery
+	}
+
+	return value.UTC(), nil
+}
+
+func (f SortField) isValid() bool {
+	switch f {
+	case SortByCreatedAt, SortByUpdatedAt, SortByUsername:
+		return true
+	default:
+		return false
+	}
+}
+
+func decodeListCursor(raw string) (*ListCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, domain.ErrInvalidQuery
+	}
+
+	var cursor ListCursor
+	if err := json.Unmarshal(decoded, &cursor); err != nil {
+		return nil, domain.ErrInvalidQuery
+	}
+	if cursor.ID == uuid.Nil || !cursor.SortField.isValid() {
+		return nil, domain.ErrInvalidQuery
+	}
+	if cursor.SortField != SortByUsername {
+		if _, err := cursor.TimeValue(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &cursor, nil
+}
@@ -1,24 +1,12 @@
 package user
 
-import (
-	"strings"
-
-	"admin.com/admin-api/internal/domain"
-)
-
-func SetTemporaryPassword(user *User, hashPassword func(password string) (string, error)) error {
-	if user == nil || strings.TrimSpace(user.Username) == "" {
-		return domain.ErrBadRequest
-	}
-	if hashPassword == nil {
-		return domain.ErrInternalServerError
-	}
-
-	hashedPassword, err := hashPassword(user.Username)
-	if err != nil {
-		return domain.ErrInternalServerError
-	}
-
-	user.PasswordHash = hashedPassword
-	return nil
+// unusablePasswordHash is not a valid hash in any format, so no password can
+// ever match it.
+const unusablePasswordHash = "!"
+
+// RequirePasswordSetup leaves the user without a working password until they
+// choose one through the invitation sent to them.
+func (u *User) RequirePasswordSetup() {
+	u.PasswordHash = unusablePasswordHash
+	u.MustChangePassword = true
 }
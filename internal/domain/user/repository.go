@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -9,7 +10,12 @@ import (
 type UserRepository interface {
 	GetUser(ctx context.Context, id uuid.UUID) (*User, error)
 	CreateUser(ctx context.Context, user *User) error
-	GetUsers(ctx context.Context) ([]User, error)
+	GetUsers(ctx context.Context, query ListQuery) ([]User, error)
+	CountUsers(ctx context.Context, filter ListFilter) (int, error)
+	SearchUsers(ctx context.Context, query SearchQuery) ([]SearchResult, error)
 	UpdateUser(ctx context.Context, user *User) error
-	DeleteUser(ctx context.Context, id uuid.UUID) error
+	DeleteUser(ctx context.Context, id uuid.UUID, deletedAt time.Time) error
+	RestoreUser(ctx context.Context, id uuid.UUID) error
+	ChangeUserStatus(ctx context.Context, change StatusChange) error
+	PurgeUser(ctx context.Context, id uuid.UUID) error
 }
@@ -0,0 +1,47 @@
+package user
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"admin.com/admin-api/internal/domain"
+)
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 50
+
+	searchTermMinLength = 2
+	searchTermMaxLength = 100
+)
+
+type SearchQuery struct {
+	Term  string
+	Limit int
+}
+
+type SearchResult struct {
+	User  User
+	Score float64
+}
+
+func NewSearchQuery(term string, limit int) (SearchQuery, error) {
+	term = strings.Join(strings.Fields(term), " ")
+
+	termLength := utf8.RuneCountInString(term)
+	if termLength < searchTermMinLength || termLength > searchTermMaxLength {
+		return SearchQuery{}, domain.ErrInvalidQuery
+	}
+
+	switch {
+	case limit == 0:
+		limit = DefaultSearchLimit
+	case limit < 0 || limit > MaxSearchLimit:
+		return SearchQuery{}, domain.ErrInvalidQuery
+	}
+
+	return SearchQuery{
+		Term:  term,
+		Limit: limit,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package user
+
+import (
+	"time"
+
+	"admin.com/admin-api/internal/domain"
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	StatusActive    Status = "active"
+	StatusSuspended Status = "suspended"
+	StatusLocked    Status = "locked"
+	StatusPending   Status = "pending"
+)
+
+var statusTransitions = map[Status][]Status{
+	StatusPending:   {StatusActive, StatusSuspended},
+	StatusActive:    {StatusSuspended, StatusLocked},
+	StatusSuspended: {StatusActive},
+	StatusLocked:    {StatusActive, StatusSuspended},
+}
+
+type StatusChange struct {
+	UserID         uuid.UUID
+	From           Status
+	To             Status
+	ChangedAt      time.Time
+	RevokeSessions bool
+}
+
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (u *User) TransitionTo(next Status, changedAt time.Time) (StatusChange, error) {
+	if u == nil || u.ID == uuid.Nil {
+		return StatusChange{}, domain.ErrBadRequest
+	}
+	if !u.Status.CanTransitionTo(next) {
+		return StatusChange{}, domain.ErrInvalidStatusTransition
+	}
+
+	change := StatusChange{
+		UserID:         u.ID,
+		From:           u.Status,
+		To:             next,
+		ChangedAt:      changedAt.UTC(),
+		RevokeSessions: next != StatusActive,
+	}
+
+	u.Status = next
+	return change, nil
+}
+
+func (u *User) EnsureCanAuthenticate() error {
+	switch u.Status {
+	case StatusActive:
+		return nil
+	case StatusSuspended:
+		return domain.ErrAccountSuspended
+	case StatusLocked:
+		return domain.ErrAccountLockedByAdmin
+	case StatusPending:
+		return domain.ErrAccountPending
+	default:
+		return domain.ErrInternalServerError
+	}
+}
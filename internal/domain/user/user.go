@@ -9,15 +9,20 @@ import (
 )
 
 type User struct {
-	ID           uuid.UUID
-	Name         string
-	LastName     string
-	Username     string
-	Email        string
-	Avatar       string
-	PasswordHash string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID                 uuid.UUID
+	Name               string
+	LastName           string
+	Username           string
+	Email              string
+	EmailVerifiedAt    *time.Time
+	Avatar             string
+	PasswordHash       string
+	MustChangePassword bool
+	Status             Status
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	DeletedAt          *time.Time
+	TokensValidAfter   *time.Time
 }
 
 type UserProfile struct {
@@ -29,7 +34,7 @@ type UserProfile struct {
 }
 
 func NewUser(profile UserProfile) (*User, error) {
-	user := &User{}
+	user := &User{Status: StatusActive}
 	if err := user.SetProfile(profile); err != nil {
 		return nil, err
 	}
@@ -37,6 +42,21 @@ func NewUser(profile UserProfile) (*User, error) {
 	return user, nil
 }
 
+// AcceptsTokenIssuedAt compares at second precision because JWT iat has no
+// fraction. A token issued in the same second as a revocation is accepted, so
+// the session created right after a password change keeps working.
+func (u *User) AcceptsTokenIssuedAt(issuedAt time.Time) bool {
+	if u.TokensValidAfter == nil {
+		return true
+	}
+
+	return !issuedAt.Truncate(time.Second).Before(u.TokensValidAfter.Truncate(time.Second))
+}
+
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
 func (u *User) SetProfile(profile UserProfile) error {
 	name := strings.TrimSpace(profile.Name)
 	lastName := strings.TrimSpace(profile.LastName)
@@ -22,12 +22,18 @@ func MapCommonBusinessError(err error) (BusinessErrorMapping, bool) {
 	switch {
 	case stderrs.Is(err, domain.ErrBadRequest):
 		return InvalidPayload, true
+	case stderrs.Is(err, domain.ErrInvalidQuery):
+		return InvalidQuery, true
 	case stderrs.Is(err, domain.ErrInvalidEmail):
 		return InvalidEmail, true
 	case stderrs.Is(err, domain.ErrUsernameExists):
 		return UsernameExists, true
 	case stderrs.Is(err, domain.ErrEmailExists):
 		return EmailExists, true
+	case stderrs.Is(err, domain.ErrForbidden):
+		return Forbidden, true
+	case stderrs.Is(err, domain.ErrTooManyRequests):
+		return TooManyRequests, true
 	case stderrs.Is(err, domain.ErrInternalServerError):
 		return Internal, true
 	default:
@@ -7,21 +7,42 @@ import (
 )
 
 var (
-	InvalidID          = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "INVALID_ID", Message: domain.BadRequestMessage}
-	InvalidPayload     = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "INVALID_PAYLOAD", Message: domain.BadRequestMessage}
-	InvalidEmail       = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "INVALID_EMAIL", Message: domain.InvalidEmailMessage}
-	WeakPassword       = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "WEAK_PASSWORD", Message: domain.WeakPasswordMessage}
-	InvalidCredentials = BusinessErrorMapping{Status: http.StatusUnauthorized, Code: "INVALID_CREDENTIALS", Message: domain.InvalidCredentialsMessage}
-	Unauthorized       = BusinessErrorMapping{Status: http.StatusUnauthorized, Code: "UNAUTHORIZED", Message: domain.UnauthorizedMessage}
-	UsernameExists     = BusinessErrorMapping{Status: http.StatusConflict, Code: "USERNAME_EXISTS", Message: domain.UsernameExistsMessage}
-	EmailExists        = BusinessErrorMapping{Status: http.StatusConflict, Code: "EMAIL_EXISTS", Message: domain.EmailExistsMessage}
-	AlreadyExists      = BusinessErrorMapping{Status: http.StatusConflict, Code: "ALREADY_EXISTS", Message: domain.ConflictMessage}
-	NotFound           = BusinessErrorMapping{Status: http.StatusNotFound, Code: "NOT_FOUND", Message: domain.NotFoundMessage}
-	Internal           = BusinessErrorMapping{Status: http.StatusInternalServerError, Code: "INTERNAL", Message: domain.InternalServerErrorMessage}
-	InvalidBody        = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "INVALID_BODY", Message: domain.BadRequestMessage}
-	MalformedJSON      = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "MALFORMED_JSON", Message: domain.BadRequestMessage}
-	InvalidFieldType   = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "INVALID_FIELD_TYPE", Message: domain.BadRequestMessage}
-	BodyTooLarge       = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "BODY_TOO_LARGE", Message: domain.BadRequestMessage}
-	InvalidContentType = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "INVALID_CONTENT_TYPE", Message: domain.BadRequestMessage}
-	MultipleJSON       = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "MULTIPLE_JSON_OBJECTS", Message: domain.BadRequestMessage}
+	InvalidID               = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "INVALID_ID", Message: domain.BadRequestMessage}
+	InvalidQuery            = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "INVALID_QUERY", Message: domain.InvalidQueryMessage}
+	InvalidPayload          = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "INVALID_PAYLOAD", Message: domain.BadRequestMessage}
+	InvalidEmail            = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "INVALID_EMAIL", Message: domain.InvalidEmailMessage}
+	WeakPassword            = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "WEAK_PASSWORD", Message: domain.WeakPasswordMessage}
+	InvalidCredentials      = BusinessErrorMapping{Status: http.StatusUnauthorized, Code: "INVALID_CREDENTIALS", Message: domain.InvalidCredentialsMessage}
+	Unauthorized            = BusinessErrorMapping{Status: http.StatusUnauthorized, Code: "UNAUTHORIZED", Message: domain.UnauthorizedMessage}
+	Forbidden               = BusinessErrorMapping{Status: http.StatusForbidden, Code: "FORBIDDEN", Message: domain.ForbiddenMessage}
+	UsernameExists          = BusinessErrorMapping{Status: http.StatusConflict, Code: "USERNAME_EXISTS", Message: domain.UsernameExistsMessage}
+	EmailExists             = BusinessErrorMapping{Status: http.StatusConflict, Code: "EMAIL_EXISTS", Message: domain.EmailExistsMessage}
+	RoleNameExists          = BusinessErrorMapping{Status: http.StatusConflict, Code: "ROLE_NAME_EXISTS", Message: domain.RoleNameExistsMessage}
+	RoleInUse               = BusinessErrorMapping{Status: http.StatusConflict, Code: "ROLE_IN_USE", Message: domain.RoleInUseMessage}
+	BuiltInRole             = BusinessErrorMapping{Status: http.StatusConflict, Code: "BUILT_IN_ROLE", Message: domain.BuiltInRoleMessage}
+	InvalidRoleName         = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "INVALID_ROLE_NAME", Message: domain.InvalidRoleNameMessage}
+	InvalidStatusTransition = BusinessErrorMapping{Status: http.StatusConflict, Code: "INVALID_STATUS_TRANSITION", Message: domain.InvalidStatusTransitionMessage}
+	UserNotDeleted          = BusinessErrorMapping{Status: http.StatusConflict, Code: "USER_NOT_DELETED", Message: domain.UserNotDeletedMessage}
+	PasswordAlreadySet      = BusinessErrorMapping{Status: http.StatusConflict, Code: "PASSWORD_ALREADY_SET", Message: domain.PasswordAlreadySetMessage}
+	AccountSuspended        = BusinessErrorMapping{Status: http.StatusForbidden, Code: "ACCOUNT_SUSPENDED", Message: domain.AccountSuspendedMessage}
+	AccountLocked           = BusinessErrorMapping{Status: http.StatusTooManyRequests, Code: "ACCOUNT_LOCKED", Message: domain.AccountLockedMessage}
+	AccountLockedByAdmin    = BusinessErrorMapping{Status: http.StatusForbidden, Code: "ACCOUNT_LOCKED_BY_ADMIN", Message: domain.AccountLockedByAdminMessage}
+	AccountPending          = BusinessErrorMapping{Status: http.StatusForbidden, Code: "ACCOUNT_PENDING", Message: domain.AccountPendingMessage}
+	RefreshTokenReused      = BusinessErrorMapping{Status: http.StatusUnauthorized, Code: "REFRESH_TOKEN_REUSED", Message: domain.RefreshTokenReusedMessage}
+	InvalidUserToken        = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "INVALID_TOKEN", Message: domain.InvalidUserTokenMessage}
+	EmailNotVerified        = BusinessErrorMapping{Status: http.StatusForbidden, Code: "EMAIL_NOT_VERIFIED", Message: domain.EmailNotVerifiedMessage}
+	InvalidCurrentPassword  = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "INVALID_CURRENT_PASSWORD", Message: domain.InvalidCurrentPasswordMessage}
+	PasswordReused          = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "PASSWORD_REUSED", Message: domain.PasswordReusedMessage}
+	MFAAlreadyEnabled       = BusinessErrorMapping{Status: http.StatusConflict, Code: "MFA_ALREADY_ENABLED", Message: domain.MFAAlreadyEnabledMessage}
+	InvalidMFACode          = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "INVALID_MFA_CODE", Message: domain.InvalidMFACodeMessage}
+	TooManyRequests         = BusinessErrorMapping{Status: http.StatusTooManyRequests, Code: "RATE_LIMITED", Message: domain.TooManyRequestsMessage}
+	AlreadyExists           = BusinessErrorMapping{Status: http.StatusConflict, Code: "ALREADY_EXISTS", Message: domain.ConflictMessage}
+	NotFound                = BusinessErrorMapping{Status: http.StatusNotFound, Code: "NOT_FOUND", Message: domain.NotFoundMessage}
+	Internal                = BusinessErrorMapping{Status: http.StatusInternalServerError, Code: "INTERNAL", Message: domain.InternalServerErrorMessage}
+	InvalidBody             = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "INVALID_BODY", Message: domain.BadRequestMessage}
+	MalformedJSON           = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "MALFORMED_JSON", Message: domain.BadRequestMessage}
+	InvalidFieldType        = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "INVALID_FIELD_TYPE", Message: domain.BadRequestMessage}
+	BodyTooLarge            = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "BODY_TOO_LARGE", Message: domain.BadRequestMessage}
+	InvalidContentType      = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "INVALID_CONTENT_TYPE", Message: domain.BadRequestMessage}
+	MultipleJSON            = BusinessErrorMapping{Status: http.StatusBadRequest, Code: "MULTIPLE_JSON_OBJECTS", Message: domain.BadRequestMessage}
 )
@@ -3,29 +3,56 @@ package auth
 import (
 	"net/http"
 
+	roledomain "admin.com/admin-api/internal/domain/role"
 	httpcookie "admin.com/admin-api/internal/http/cookie"
+	"admin.com/admin-api/internal/http/middleware"
 	authusecase "admin.com/admin-api/internal/usecase/auth"
 )
 
 type AuthHandler struct {
-	useCase      authusecase.AuthUseCase
-	cookieConfig httpcookie.CookieConfig
+	useCase       authusecase.AuthUseCase
+	cookieConfig  httpcookie.CookieConfig
+	authenticator *middleware.Authenticator
 }
 
-func NewAuthHandler(mux *http.ServeMux, useCase authusecase.AuthUseCase, cookieConfig httpcookie.CookieConfig) *AuthHandler {
+func NewAuthHandler(
+	mux *http.ServeMux,
+	useCase authusecase.AuthUseCase,
+	cookieConfig httpcookie.CookieConfig,
+	authenticator *middleware.Authenticator,
+) *AuthHandler {
 	handler := &AuthHandler{
-		useCase:      useCase,
-		cookieConfig: cookieConfig,
+		useCase:       useCase,
+		cookieConfig:  cookieConfig,
+		authenticator: authenticator,
 	}
 
 	handler.RegisterRoutes(mux)
 	return handler
 }
 
+// RegisterRoutes keeps the credential endpoints public and lets any
+// authenticated caller manage their own sessions, password and second factor,
+// so a user without roles can still secure their account; only the per-user
+// session routes used by support staff need a permission.
 func (h *AuthHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /auth/register", h.Register)
 	mux.HandleFunc("POST /auth/login", h.Login)
 	mux.HandleFunc("POST /auth/refresh", h.Refresh)
 	mux.HandleFunc("POST /auth/logout", h.Logout)
-	mux.HandleFunc("GET /auth/me", h.Me)
+	mux.HandleFunc("POST /auth/verify-email", h.VerifyEmail)
+	mux.HandleFunc("POST /auth/resend-verification", h.ResendVerification)
+	mux.HandleFunc("POST /auth/password/forgot", h.ForgotPassword)
+	mux.HandleFunc("POST /auth/password/reset", h.ResetPassword)
+	mux.HandleFunc("POST /auth/invitation/accept", h.AcceptInvitation)
+	mux.Handle("POST /auth/password/change", h.authenticator.Authenticate(http.HandlerFunc(h.ChangePassword)))
+	mux.HandleFunc("POST /auth/mfa/challenge", h.CompleteMFAChallenge)
+	mux.Handle("POST /auth/mfa/totp/setup", h.authenticator.Authenticate(http.HandlerFunc(h.SetupTOTP)))
+	mux.Handle("POST /auth/mfa/totp/verify", h.authenticator.Authenticate(http.HandlerFunc(h.VerifyTOTP)))
+	mux.Handle("GET /auth/me", h.authenticator.Authenticate(http.HandlerFunc(h.Me)))
+	mux.Handle("GET /auth/sessions", h.authenticator.Authenticate(http.HandlerFunc(h.ListSessions)))
+	mux.Handle("DELETE /auth/sessions/{familyId}", h.authenticator.Authenticate(http.HandlerFunc(h.RevokeSession)))
+	mux.Handle("POST /auth/logout-all", h.authenticator.Authenticate(http.HandlerFunc(h.LogoutAll)))
+	mux.Handle("GET /users/{id}/sessions", h.authenticator.RequirePermission(http.HandlerFunc(h.ListUserSessions), roledomain.PermissionUsersSessions))
+	mux.Handle("DELETE /users/{id}/sessions", h.authenticator.RequirePermission(http.HandlerFunc(h.RevokeUserSessions), roledomain.PermissionUsersSessions))
 }
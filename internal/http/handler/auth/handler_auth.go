@@ -1,19 +1,22 @@
 package auth
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"admin.com/admin-api/internal/domain"
 	httpcookie "admin.com/admin-api/internal/http/cookie"
 	"admin.com/admin-api/internal/http/decoder"
+	"admin.com/admin-api/internal/http/middleware"
 	httprequest "admin.com/admin-api/internal/http/request"
 	"admin.com/admin-api/internal/http/response"
 	authusecase "admin.com/admin-api/internal/usecase/auth"
 )
 
-const authorizationBearerPrefix = "Bearer "
-
 func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var req httprequest.RegisterInput
 	if err := decoder.DecodeBody(w, r, &req); err != nil {
@@ -44,16 +47,23 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session, err := h.useCase.Login(r.Context(), authusecase.LoginInput{
-		Identity: req.Identity,
-		Password: req.Password,
+	login, err := h.useCase.Login(r.Context(), authusecase.LoginInput{
+		Identity:  req.Identity,
+		Password:  req.Password,
+		UserAgent: r.UserAgent(),
+		IPAddress: middleware.ClientIP(r),
 	})
 	if err != nil {
 		writeAuthBusinessError(w, r, err)
 		return
 	}
 
-	h.writeSession(w, http.StatusOK, session)
+	if login.MFAChallenge != nil {
+		response.WriteSuccess(w, http.StatusOK, response.FromMFAChallenge(*login.MFAChallenge))
+		return
+	}
+
+	h.writeSession(w, http.StatusOK, login.Session)
 }
 
 func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
@@ -65,6 +75,9 @@ func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
 
 	session, err := h.useCase.Refresh(r.Context(), refreshToken)
 	if err != nil {
+		if errors.Is(err, domain.ErrRefreshTokenReused) {
+			httpcookie.ClearRefreshToken(w, h.cookieConfig)
+		}
 		writeAuthBusinessError(w, r, err)
 		return
 	}
@@ -73,8 +86,10 @@ func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
-	if refreshToken, ok := h.refreshTokenFromCookie(r); ok {
-		if err := h.useCase.Logout(r.Context(), refreshToken); err != nil {
+	refreshToken, hasRefreshToken := h.refreshTokenFromCookie(r)
+	accessToken, hasAccessToken := middleware.BearerToken(r.Header.Get("Authorization"))
+	if hasRefreshToken || hasAccessToken {
+		if err := h.useCase.Logout(r.Context(), refreshToken, accessToken); err != nil {
 			writeAuthBusinessError(w, r, err)
 			return
 		}
@@ -85,7 +100,7 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
-	accessToken, ok := accessTokenFromAuthorization(r.Header.Get("Authorization"))
+	accessToken, ok := middleware.BearerToken(r.Header.Get("Authorization"))
 	if !ok {
 		writeAuthBusinessError(w, r, domain.ErrUnauthorized)
 		return
@@ -119,20 +134,7 @@ func (h *AuthHandler) refreshTokenFromCookie(r *http.Request) (string, bool) {
 	return token, true
 }
 
-func accessTokenFromAuthorization(authorizationHeader string) (string, bool) {
-	authorizationHeader = strings.TrimSpace(authorizationHeader)
-	if authorizationHeader == "" {
-		return "", false
-	}
-
-	if !strings.HasPrefix(authorizationHeader, authorizationBearerPrefix) {
-		return "", false
-	}
-
-	token := strings.TrimSpace(strings.TrimPrefix(authorizationHeader, authorizationBearerPrefix))
-	if token == "" {
-		return "", false
-	}
-
-	return token, true
+// retryAfterSeconds rounds up so clients never retry before the lock ends.
+func retryAfterSeconds(retryAfter time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))
 }
@@ -5,11 +5,18 @@ import (
 	"net/http"
 
 	"admin.com/admin-api/internal/domain"
+	domainauth "admin.com/admin-api/internal/domain/auth"
 	httpErrors "admin.com/admin-api/internal/http/errors"
 	appLogger "admin.com/admin-api/pkg/logger"
 )
 
+// writeAuthBusinessError also tells throttled clients when to retry.
 func writeAuthBusinessError(w http.ResponseWriter, r *http.Request, err error) {
+	var lockedErr *domainauth.LoginLockedError
+	if errors.As(err, &lockedErr) {
+		w.Header().Set("Retry-After", retryAfterSeconds(lockedErr.RetryAfter))
+	}
+
 	httpErrors.WriteBusinessError(w, r, err, appLogger.MsgAuthRequestFailed, mapAuthBusinessError)
 }
 
@@ -22,10 +29,34 @@ func mapAuthBusinessError(err error) httpErrors.BusinessErrorMapping {
 	switch {
 	case errors.Is(err, domain.ErrWeakPassword):
 		return httpErrors.WeakPassword
+	case errors.Is(err, domain.ErrAccountSuspended):
+		return httpErrors.AccountSuspended
+	case errors.Is(err, domain.ErrAccountLocked):
+		return httpErrors.AccountLocked
+	case errors.Is(err, domain.ErrAccountLockedByAdmin):
+		return httpErrors.AccountLockedByAdmin
+	case errors.Is(err, domain.ErrAccountPending):
+		return httpErrors.AccountPending
+	case errors.Is(err, domain.ErrEmailNotVerified):
+		return httpErrors.EmailNotVerified
+	case errors.Is(err, domain.ErrInvalidCurrentPassword):
+		return httpErrors.InvalidCurrentPassword
+	case errors.Is(err, domain.ErrPasswordReused):
+		return httpErrors.PasswordReused
+	case errors.Is(err, domain.ErrMFAAlreadyEnabled):
+		return httpErrors.MFAAlreadyEnabled
+	case errors.Is(err, domain.ErrInvalidMFACode):
+		return httpErrors.InvalidMFACode
+	case errors.Is(err, domain.ErrInvalidUserToken):
+		return httpErrors.InvalidUserToken
+	case errors.Is(err, domain.ErrRefreshTokenReused):
+		return httpErrors.RefreshTokenReused
 	case errors.Is(err, domain.ErrInvalidCredentials):
 		return httpErrors.InvalidCredentials
 	case errors.Is(err, domain.ErrUnauthorized):
 		return httpErrors.Unauthorized
+	case errors.Is(err, domain.ErrNotFound):
+		return httpErrors.NotFound
 	default:
 		return httpErrors.Internal
 	}
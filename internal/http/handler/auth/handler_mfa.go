@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"net/http"
+
+	"admin.com/admin-api/internal/domain"
+	"admin.com/admin-api/internal/http/decoder"
+	"admin.com/admin-api/internal/http/middleware"
+	httprequest "admin.com/admin-api/internal/http/request"
+	"admin.com/admin-api/internal/http/response"
+	authusecase "admin.com/admin-api/internal/usecase/auth"
+)
+
+func (h *AuthHandler) SetupTOTP(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		writeAuthBusinessError(w, r, domain.ErrUnauthorized)
+		return
+	}
+
+	var req httprequest.SetupTOTPInput
+	if err := decoder.DecodeBody(w, r, &req); err != nil {
+		decoder.WriteDecodeError(w, err)
+		return
+	}
+
+	setup, err := h.useCase.SetupTOTP(r.Context(), authusecase.SetupTOTPInput{
+		UserID:          principal.UserID,
+		CurrentPassword: req.CurrentPassword,
+		IPAddress:       middleware.ClientIP(r),
+	})
+	if err != nil {
+		writeAuthBusinessError(w, r, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.FromTOTPSetup(*setup))
+}
+
+func (h *AuthHandler) VerifyTOTP(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		writeAuthBusinessError(w, r, domain.ErrUnauthorized)
+		return
+	}
+
+	var req httprequest.VerifyTOTPInput
+	if err := decoder.DecodeBody(w, r, &req); err != nil {
+		decoder.WriteDecodeError(w, err)
+		return
+	}
+
+	recoveryCodes, err := h.useCase.VerifyTOTP(r.Context(), authusecase.VerifyTOTPInput{
+		UserID:    principal.UserID,
+		Code:      req.Code,
+		IPAddress: middleware.ClientIP(r),
+	})
+	if err != nil {
+		writeAuthBusinessError(w, r, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.RecoveryCodesOutput{RecoveryCodes: recoveryCodes})
+}
+
+func (h *AuthHandler) CompleteMFAChallenge(w http.ResponseWriter, r *http.Request) {
+	var req httprequest.MFAChallengeInput
+	if err := decoder.DecodeBody(w, r, &req); err != nil {
+		decoder.WriteDecodeError(w, err)
+		return
+	}
+
+	session, err := h.useCase.CompleteMFAChallenge(r.Context(), authusecase.MFAChallengeInput{
+		ChallengeToken: req.ChallengeToken,
+		Code:           req.Code,
+		RecoveryCode:   req.RecoveryCode,
+		UserAgent:      r.UserAgent(),
+		IPAddress:      middleware.ClientIP(r),
+	})
+	if err != nil {
+		writeAuthBusinessError(w, r, err)
+		return
+	}
+
+	h.writeSession(w, http.StatusOK, session)
+}
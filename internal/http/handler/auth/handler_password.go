@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"log/slog"
+	"net/http"
+
+	"admin.com/admin-api/internal/domain"
+	"admin.com/admin-api/internal/http/decoder"
+	"admin.com/admin-api/internal/http/middleware"
+	httprequest "admin.com/admin-api/internal/http/request"
+	authusecase "admin.com/admin-api/internal/usecase/auth"
+	appLogger "admin.com/admin-api/pkg/logger"
+)
+
+// ForgotPassword answers 202 even when the reset email could not be sent:
+// any other answer would tell callers that the address has an account.
+func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req httprequest.ForgotPasswordInput
+	if err := decoder.DecodeBody(w, r, &req); err != nil {
+		decoder.WriteDecodeError(w, err)
+		return
+	}
+
+	if err := h.useCase.ForgotPassword(r.Context(), req.Email); err != nil {
+		slog.Error(appLogger.MsgAuthRequestFailed,
+			"request_id", middleware.RequestIDFromContext(r.Context()),
+			"method", r.Method,
+			"path", r.URL.Path,
+			"error", err,
+		)
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		writeAuthBusinessError(w, r, domain.ErrUnauthorized)
+		return
+	}
+
+	var req httprequest.ChangePasswordInput
+	if err := decoder.DecodeBody(w, r, &req); err != nil {
+		decoder.WriteDecodeError(w, err)
+		return
+	}
+
+	currentRefreshToken, _ := h.refreshTokenFromCookie(r)
+	if err := h.useCase.ChangePassword(r.Context(), authusecase.ChangePasswordInput{
+		UserID:              principal.UserID,
+		CurrentPassword:     req.CurrentPassword,
+		NewPassword:         req.NewPassword,
+		CurrentRefreshToken: currentRefreshToken,
+		RevokeOtherSessions: req.RevokeOtherSessions,
+	}); err != nil {
+		writeAuthBusinessError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req httprequest.ResetPasswordInput
+	if err := decoder.DecodeBody(w, r, &req); err != nil {
+		decoder.WriteDecodeError(w, err)
+		return
+	}
+
+	if err := h.useCase.ResetPassword(r.Context(), authusecase.SetPasswordInput{
+		Token:    req.Token,
+		Password: req.Password,
+	}); err != nil {
+		writeAuthBusinessError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AuthHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	var req httprequest.AcceptInvitationInput
+	if err := decoder.DecodeBody(w, r, &req); err != nil {
+		decoder.WriteDecodeError(w, err)
+		return
+	}
+
+	if err := h.useCase.AcceptInvitation(r.Context(), authusecase.SetPasswordInput{
+		Token:    req.Token,
+		Password: req.Password,
+	}); err != nil {
+		writeAuthBusinessError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"net/http"
+
+	"admin.com/admin-api/internal/domain"
+	httpcookie "admin.com/admin-api/internal/http/cookie"
+	httpErrors "admin.com/admin-api/internal/http/errors"
+	"admin.com/admin-api/internal/http/middleware"
+	"admin.com/admin-api/internal/http/response"
+	"github.com/google/uuid"
+)
+
+func (h *AuthHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		writeAuthBusinessError(w, r, domain.ErrUnauthorized)
+		return
+	}
+
+	currentRefreshToken, _ := h.refreshTokenFromCookie(r)
+	sessions, err := h.useCase.ListSessions(r.Context(), principal.UserID, currentRefreshToken)
+	if err != nil {
+		writeAuthBusinessError(w, r, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.FromActiveSessions(sessions))
+}
+
+func (h *AuthHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		writeAuthBusinessError(w, r, domain.ErrUnauthorized)
+		return
+	}
+
+	familyID, ok := uuidFromPath(w, r, "familyId")
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.RevokeSession(r.Context(), principal.UserID, familyID); err != nil {
+		writeAuthBusinessError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AuthHandler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		writeAuthBusinessError(w, r, domain.ErrUnauthorized)
+		return
+	}
+
+	if err := h.useCase.LogoutAll(r.Context(), principal.UserID); err != nil {
+		writeAuthBusinessError(w, r, err)
+		return
+	}
+
+	httpcookie.ClearRefreshToken(w, h.cookieConfig)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AuthHandler) ListUserSessions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := uuidFromPath(w, r, "id")
+	if !ok {
+		return
+	}
+
+	sessions, err := h.useCase.ListUserSessions(r.Context(), userID)
+	if err != nil {
+		writeAuthBusinessError(w, r, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.FromActiveSessions(sessions))
+}
+
+func (h *AuthHandler) RevokeUserSessions(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		writeAuthBusinessError(w, r, domain.ErrUnauthorized)
+		return
+	}
+
+	userID, ok := uuidFromPath(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.RevokeAllUserSessions(r.Context(), principal.UserID, userID); err != nil {
+		writeAuthBusinessError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func uuidFromPath(w http.ResponseWriter, r *http.Request, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(r.PathValue(name))
+	if err != nil {
+		response.WriteErrorWithCode(w, httpErrors.InvalidID.Status, httpErrors.InvalidID.Code, httpErrors.InvalidID.Message)
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
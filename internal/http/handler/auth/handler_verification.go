@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"net/http"
+
+	"admin.com/admin-api/internal/http/decoder"
+	httprequest "admin.com/admin-api/internal/http/request"
+)
+
+func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req httprequest.VerifyEmailInput
+	if err := decoder.DecodeBody(w, r, &req); err != nil {
+		decoder.WriteDecodeError(w, err)
+		return
+	}
+
+	if err := h.useCase.VerifyEmail(r.Context(), req.Token); err != nil {
+		writeAuthBusinessError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ResendVerification always answers 202 for a valid address, whether or not
+// an email was actually sent.
+func (h *AuthHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	var req httprequest.ResendVerificationInput
+	if err := decoder.DecodeBody(w, r, &req); err != nil {
+		decoder.WriteDecodeError(w, err)
+		return
+	}
+
+	if err := h.useCase.ResendVerification(r.Context(), req.Email); err != nil {
+		writeAuthBusinessError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
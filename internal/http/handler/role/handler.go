@@ -0,0 +1,30 @@
+package role
+
+import (
+	"net/http"
+
+	roledomain "admin.com/admin-api/internal/domain/role"
+	"admin.com/admin-api/internal/http/middleware"
+	roleusecase "admin.com/admin-api/internal/usecase/role"
+)
+
+type RoleHandler struct {
+	useCase roleusecase.RoleUseCase
+}
+
+func NewRoleHandler(mux *http.ServeMux, useCase roleusecase.RoleUseCase, authenticator *middleware.Authenticator) {
+	handler := &RoleHandler{
+		useCase: useCase,
+	}
+
+	mux.Handle("GET /roles/{id}", authenticator.RequirePermission(http.HandlerFunc(handler.GetRole), roledomain.PermissionRolesRead))
+	mux.Handle("GET /roles", authenticator.RequirePermission(http.HandlerFunc(handler.GetRoles), roledomain.PermissionRolesRead))
+	mux.Handle("POST /roles", authenticator.RequirePermission(http.HandlerFunc(handler.CreateRole), roledomain.PermissionRolesWrite))
+	mux.Handle("PUT /roles/{id}", authenticator.RequirePermission(http.HandlerFunc(handler.UpdateRole), roledomain.PermissionRolesWrite))
+	mux.Handle("DELETE /roles/{id}", authenticator.RequirePermission(http.HandlerFunc(handler.DeleteRole), roledomain.PermissionRolesWrite))
+	mux.Handle("GET /roles/{id}/permissions", authenticator.RequirePermission(http.HandlerFunc(handler.GetRolePermissions), roledomain.PermissionRolesRead))
+	mux.Handle("PUT /roles/{id}/permissions/{permission}", authenticator.RequirePermission(http.HandlerFunc(handler.GrantPermission), roledomain.PermissionRolesWrite))
+	mux.Handle("DELETE /roles/{id}/permissions/{permission}", authenticator.RequirePermission(http.HandlerFunc(handler.RevokePermission), roledomain.PermissionRolesWrite))
+	mux.Handle("PUT /users/{id}/roles/{roleId}", authenticator.RequirePermission(http.HandlerFunc(handler.AssignRole), roledomain.PermissionRolesAssign))
+	mux.Handle("DELETE /users/{id}/roles/{roleId}", authenticator.RequirePermission(http.HandlerFunc(handler.RevokeRole), roledomain.PermissionRolesAssign))
+}
@@ -0,0 +1,38 @@
+package role
+
+import (
+	"errors"
+	"net/http"
+
+	"admin.com/admin-api/internal/domain"
+	httpErrors "admin.com/admin-api/internal/http/errors"
+	appLogger "admin.com/admin-api/pkg/logger"
+)
+
+func writeRoleBusinessError(w http.ResponseWriter, r *http.Request, err error) {
+	httpErrors.WriteBusinessError(w, r, err, appLogger.MsgRoleRequestFailed, mapRoleBusinessError)
+}
+
+func mapRoleBusinessError(err error) httpErrors.BusinessErrorMapping {
+	mapped, ok := httpErrors.MapCommonBusinessError(err)
+	if ok {
+		return mapped
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrInvalidRoleName):
+		return httpErrors.InvalidRoleName
+	case errors.Is(err, domain.ErrRoleNameExists):
+		return httpErrors.RoleNameExists
+	case errors.Is(err, domain.ErrRoleInUse):
+		return httpErrors.RoleInUse
+	case errors.Is(err, domain.ErrBuiltInRole):
+		return httpErrors.BuiltInRole
+	case errors.Is(err, domain.ErrConflict):
+		return httpErrors.AlreadyExists
+	case errors.Is(err, domain.ErrNotFound):
+		return httpErrors.NotFound
+	default:
+		return httpErrors.Internal
+	}
+}
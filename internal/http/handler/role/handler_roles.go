@@ -0,0 +1,189 @@
+package role
+
+import (
+	"net/http"
+
+	"admin.com/admin-api/internal/http/decoder"
+	httpErrors "admin.com/admin-api/internal/http/errors"
+	httprequest "admin.com/admin-api/internal/http/request"
+	"admin.com/admin-api/internal/http/response"
+	roleusecase "admin.com/admin-api/internal/usecase/role"
+	"github.com/google/uuid"
+)
+
+func (h *RoleHandler) GetRole(w http.ResponseWriter, r *http.Request) {
+	id, ok := uuidFromPath(w, r, "id")
+	if !ok {
+		return
+	}
+
+	role, err := h.useCase.GetRole(r.Context(), id)
+	if err != nil {
+		writeRoleBusinessError(w, r, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.FromRole(*role))
+}
+
+func (h *RoleHandler) GetRoles(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.useCase.GetRoles(r.Context())
+	if err != nil {
+		writeRoleBusinessError(w, r, err)
+		return
+	}
+
+	roleOutputs := make([]response.RoleOutput, len(roles))
+	for i, role := range roles {
+		roleOutputs[i] = response.FromRole(role)
+	}
+
+	response.WriteSuccess(w, http.StatusOK, roleOutputs)
+}
+
+func (h *RoleHandler) CreateRole(w http.ResponseWriter, r *http.Request) {
+	var req httprequest.CreateRoleInput
+	if err := decoder.DecodeBody(w, r, &req); err != nil {
+		decoder.WriteDecodeError(w, err)
+		return
+	}
+
+	role, err := h.useCase.CreateRole(r.Context(), roleusecase.CreateRoleInput{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		writeRoleBusinessError(w, r, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusCreated, response.FromRole(*role))
+}
+
+func (h *RoleHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	id, ok := uuidFromPath(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req httprequest.UpdateRoleInput
+	if err := decoder.DecodeBody(w, r, &req); err != nil {
+		decoder.WriteDecodeError(w, err)
+		return
+	}
+
+	role, err := h.useCase.UpdateRole(r.Context(), roleusecase.UpdateRoleInput{
+		ID:          id,
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		writeRoleBusinessError(w, r, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.FromRole(*role))
+}
+
+func (h *RoleHandler) DeleteRole(w http.ResponseWriter, r *http.Request) {
+	id, ok := uuidFromPath(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.DeleteRole(r.Context(), id); err != nil {
+		writeRoleBusinessError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *RoleHandler) AssignRole(w http.ResponseWriter, r *http.Request) {
+	userID, ok := uuidFromPath(w, r, "id")
+	if !ok {
+		return
+	}
+	roleID, ok := uuidFromPath(w, r, "roleId")
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.AssignRole(r.Context(), userID, roleID); err != nil {
+		writeRoleBusinessError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *RoleHandler) RevokeRole(w http.ResponseWriter, r *http.Request) {
+	userID, ok := uuidFromPath(w, r, "id")
+	if !ok {
+		return
+	}
+	roleID, ok := uuidFromPath(w, r, "roleId")
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.RevokeRole(r.Context(), userID, roleID); err != nil {
+		writeRoleBusinessError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *RoleHandler) GetRolePermissions(w http.ResponseWriter, r *http.Request) {
+	roleID, ok := uuidFromPath(w, r, "id")
+	if !ok {
+		return
+	}
+
+	permissions, err := h.useCase.GetRolePermissions(r.Context(), roleID)
+	if err != nil {
+		writeRoleBusinessError(w, r, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, permissions)
+}
+
+func (h *RoleHandler) GrantPermission(w http.ResponseWriter, r *http.Request) {
+	roleID, ok := uuidFromPath(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.GrantPermission(r.Context(), roleID, r.PathValue("permission")); err != nil {
+		writeRoleBusinessError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *RoleHandler) RevokePermission(w http.ResponseWriter, r *http.Request) {
+	roleID, ok := uuidFromPath(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.RevokePermission(r.Context(), roleID, r.PathValue("permission")); err != nil {
+		writeRoleBusinessError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func uuidFromPath(w http.ResponseWriter, r *http.Request, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(r.PathValue(name))
+	if err != nil {
+		response.WriteErrorWithCode(w, httpErrors.InvalidID.Status, httpErrors.InvalidID.Code, httpErrors.InvalidID.Message)
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
@@ -3,6 +3,8 @@ package user
 import (
 	"net/http"
 
+	roledomain "admin.com/admin-api/internal/domain/role"
+	"admin.com/admin-api/internal/http/middleware"
 	userusecase "admin.com/admin-api/internal/usecase/user"
 )
 
@@ -10,14 +12,21 @@ type UserHandler struct {
 	useCase userusecase.UserUseCase
 }
 
-func NewUserHandler(mux *http.ServeMux, useCase userusecase.UserUseCase) {
+func NewUserHandler(mux *http.ServeMux, useCase userusecase.UserUseCase, authenticator *middleware.Authenticator) {
 	handler := &UserHandler{
 		useCase: useCase,
 	}
 
-	mux.HandleFunc("GET /users/{id}", handler.GetUser)
-	mux.HandleFunc("GET /users", handler.GetUsers)
-	mux.HandleFunc("POST /users", handler.CreateUser)
-	mux.HandleFunc("PUT /users/{id}", handler.UpdateUser)
-	mux.HandleFunc("DELETE /users/{id}", handler.DeleteUser)
+	mux.Handle("GET /users/search", authenticator.RequirePermission(http.HandlerFunc(handler.SearchUsers), roledomain.PermissionUsersRead))
+	mux.Handle("GET /users/{id}", authenticator.RequirePermission(http.HandlerFunc(handler.GetUser), roledomain.PermissionUsersRead))
+	mux.Handle("GET /users", authenticator.RequirePermission(http.HandlerFunc(handler.GetUsers), roledomain.PermissionUsersRead))
+	mux.Handle("POST /users", authenticator.RequirePermission(http.HandlerFunc(handler.CreateUser), roledomain.PermissionUsersWrite))
+	mux.Handle("PUT /users/{id}", authenticator.RequirePermission(http.HandlerFunc(handler.UpdateUser), roledomain.PermissionUsersWrite))
+	mux.Handle("DELETE /users/{id}", authenticator.RequirePermission(http.HandlerFunc(handler.DeleteUser), roledomain.PermissionUsersWrite))
+	mux.Handle("POST /users/{id}/restore", authenticator.RequirePermission(http.HandlerFunc(handler.RestoreUser), roledomain.PermissionUsersWrite))
+	mux.Handle("POST /users/{id}/suspend", authenticator.RequirePermission(http.HandlerFunc(handler.SuspendUser), roledomain.PermissionUsersWrite))
+	mux.Handle("POST /users/{id}/activate", authenticator.RequirePermission(http.HandlerFunc(handler.ActivateUser), roledomain.PermissionUsersWrite))
+	mux.Handle("POST /users/{id}/lock", authenticator.RequirePermission(http.HandlerFunc(handler.LockUser), roledomain.PermissionUsersWrite))
+	mux.Handle("POST /users/{id}/invitation", authenticator.RequirePermission(http.HandlerFunc(handler.ResendInvitation), roledomain.PermissionUsersWrite))
+	mux.Handle("DELETE /users/{id}/purge", authenticator.RequirePermission(http.HandlerFunc(handler.PurgeUser), roledomain.PermissionUsersPurge))
 }
@@ -20,6 +20,12 @@ func mapUserBusinessError(err error) httpErrors.BusinessErrorMapping {
 	}
 
 	switch {
+	case errors.Is(err, domain.ErrInvalidStatusTransition):
+		return httpErrors.InvalidStatusTransition
+	case errors.Is(err, domain.ErrUserNotDeleted):
+		return httpErrors.UserNotDeleted
+	case errors.Is(err, domain.ErrPasswordAlreadySet):
+		return httpErrors.PasswordAlreadySet
 	case errors.Is(err, domain.ErrConflict):
 		return httpErrors.AlreadyExists
 	case errors.Is(err, domain.ErrNotFound):
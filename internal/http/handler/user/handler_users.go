@@ -2,6 +2,8 @@ package user
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"admin.com/admin-api/internal/http/decoder"
 	httpErrors "admin.com/admin-api/internal/http/errors"
@@ -49,18 +51,48 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *UserHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
-	users, err := h.useCase.GetUsers(r.Context())
+	query := r.URL.Query()
+
+	limit, ok := limitFromQuery(w, query.Get("limit"))
+	if !ok {
+		return
+	}
+
+	page, err := h.useCase.GetUsers(r.Context(), userusecase.ListUsersInput{
+		Limit:          limit,
+		Cursor:         query.Get("cursor"),
+		Sort:           query.Get("sort"),
+		Order:          query.Get("order"),
+		EmailPrefix:    query.Get("email"),
+		UsernamePrefix: query.Get("username"),
+	})
 	if err != nil {
 		writeUserBusinessError(w, r, err)
 		return
 	}
 
-	userOutputs := make([]response.UserOutput, len(users))
-	for i, user := range users {
-		userOutputs[i] = response.FromUser(user)
+	userOutputs, meta := response.FromUserPage(*page)
+	response.WriteSuccessWithMeta(w, http.StatusOK, userOutputs, meta)
+}
+
+func (h *UserHandler) SearchUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit, ok := limitFromQuery(w, query.Get("limit"))
+	if !ok {
+		return
 	}
 
-	response.WriteSuccess(w, http.StatusOK, userOutputs)
+	results, err := h.useCase.SearchUsers(r.Context(), userusecase.SearchUsersInput{
+		Query: query.Get("q"),
+		Limit: limit,
+	})
+	if err != nil {
+		writeUserBusinessError(w, r, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.FromUserSearch(results))
 }
 
 func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
@@ -77,6 +109,94 @@ func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *UserHandler) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.useCase.RestoreUser(r.Context(), id)
+	if err != nil {
+		writeUserBusinessError(w, r, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.FromUser(*user))
+}
+
+func (h *UserHandler) PurgeUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.PurgeUser(r.Context(), id); err != nil {
+		writeUserBusinessError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *UserHandler) ResendInvitation(w http.ResponseWriter, r *http.Request) {
+	id, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.ResendInvitation(r.Context(), id); err != nil {
+		writeUserBusinessError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *UserHandler) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.useCase.SuspendUser(r.Context(), id)
+	if err != nil {
+		writeUserBusinessError(w, r, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.FromUser(*user))
+}
+
+func (h *UserHandler) ActivateUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.useCase.ActivateUser(r.Context(), id)
+	if err != nil {
+		writeUserBusinessError(w, r, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.FromUser(*user))
+}
+
+func (h *UserHandler) LockUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.useCase.LockUser(r.Context(), id)
+	if err != nil {
+		writeUserBusinessError(w, r, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.FromUser(*user))
+}
+
 func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	id, ok := userIDFromPath(w, r)
 	if !ok {
@@ -115,3 +235,18 @@ func userIDFromPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
 
 	return id, true
 }
+
+func limitFromQuery(w http.ResponseWriter, raw string) (int, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return 0, true
+	}
+
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit <= 0 {
+		response.WriteErrorWithCode(w, httpErrors.InvalidQuery.Status, httpErrors.InvalidQuery.Code, httpErrors.InvalidQuery.Message)
+		return 0, false
+	}
+
+	return limit, true
+}
@@ -0,0 +1,27 @@
+package wellknown
+
+import (
+	"net/http"
+
+	domainauth "admin.com/admin-api/internal/domain/auth"
+	"admin.com/admin-api/internal/http/response"
+)
+
+type WellKnownHandler struct {
+	keys domainauth.PublicKeySource
+}
+
+func NewWellKnownHandler(mux *http.ServeMux, keys domainauth.PublicKeySource) {
+	handler := &WellKnownHandler{
+		keys: keys,
+	}
+
+	mux.HandleFunc("GET /.well-known/jwks.json", handler.JWKS)
+}
+
+// JWKS is a standard document read by other services, so it is written
+// without the success envelope.
+func (h *WellKnownHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	response.WriteJSON(w, http.StatusOK, response.FromPublicKeys(h.keys.PublicKeys()))
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"admin.com/admin-api/internal/domain"
+	domainauth "admin.com/admin-api/internal/domain/auth"
+	roledomain "admin.com/admin-api/internal/domain/role"
+	appLogger "admin.com/admin-api/pkg/logger"
+	"github.com/google/uuid"
+)
+
+const authorizationBearerPrefix = "Bearer "
+
+type Principal struct {
+	UserID      uuid.UUID
+	TokenID     string
+	Roles       []string
+	Permissions []string
+}
+
+func (p Principal) HasPermission(permission string) bool {
+	return roledomain.HasPermission(p.Permissions, permission)
+}
+
+type principalKey struct{}
+
+type AccessTokenVerifier interface {
+	Authenticate(ctx context.Context, accessToken string) (*domainauth.AccessTokenClaims, error)
+}
+
+// Authenticator builds the principal from the verified access token claims;
+// roles and permissions come from the token, not from the database.
+type Authenticator struct {
+	verifier AccessTokenVerifier
+}
+
+func NewAuthenticator(verifier AccessTokenVerifier) *Authenticator {
+	return &Authenticator{
+		verifier: verifier,
+	}
+}
+
+func (a *Authenticator) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accessToken, ok := BearerToken(r.Header.Get("Authorization"))
+		if !ok {
+			writeUnauthorized(w)
+			return
+		}
+
+		claims, err := a.verifier.Authenticate(r.Context(), accessToken)
+		if err != nil {
+			if errors.Is(err, domain.ErrUnauthorized) {
+				writeUnauthorized(w)
+				return
+			}
+
+			slog.Error(appLogger.MsgAuthenticationFailed,
+				"request_id", RequestIDFromContext(r.Context()),
+				"method", r.Method,
+				"path", r.URL.Path,
+				"error", err,
+			)
+			writeInternal(w)
+			return
+		}
+
+		userID, err := uuid.Parse(claims.Subject)
+		if err != nil {
+			writeUnauthorized(w)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), principalKey{}, Principal{
+			UserID:      userID,
+			TokenID:     claims.TokenID,
+			Roles:       claims.Roles,
+			Permissions: claims.Permissions,
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (a *Authenticator) RequirePermission(next http.Handler, permission string) http.Handler {
+	return a.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := PrincipalFromContext(r.Context())
+		if !ok {
+			writeUnauthorized(w)
+			return
+		}
+
+		if !principal.HasPermission(permission) {
+			writeForbidden(w)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	}))
+}
+
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	if !ok || principal.UserID == uuid.Nil {
+		return Principal{}, false
+	}
+
+	return principal, true
+}
+
+func BearerToken(authorizationHeader string) (string, bool) {
+	authorizationHeader = strings.TrimSpace(authorizationHeader)
+	if authorizationHeader == "" {
+		return "", false
+	}
+
+	if !strings.HasPrefix(authorizationHeader, authorizationBearerPrefix) {
+		return "", false
+	}
+
+	token := strings.TrimSpace(strings.TrimPrefix(authorizationHeader, authorizationBearerPrefix))
+	if token == "" {
+		return "", false
+	}
+
+	return token, true
+}
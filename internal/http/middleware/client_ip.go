@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+type clientIPKey struct{}
+
+// ClientIPMiddleware resolves the client address once per request. The
+// forwarding headers are only believed when the connection comes from one of
+// trustedProxies; anyone else could otherwise choose the address that login
+// lockouts and rate limits are keyed by.
+func ClientIPMiddleware(next http.Handler, trustedProxies []netip.Prefix) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), clientIPKey{}, resolveClientIP(r, trustedProxies))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ClientIP returns the address resolved by ClientIPMiddleware, or the peer
+// address for requests that did not go through it.
+func ClientIP(r *http.Request) string {
+	if clientIP, ok := r.Context().Value(clientIPKey{}).(string); ok {
+		return clientIP
+	}
+
+	return remoteIP(r)
+}
+
+// resolveClientIP walks X-Forwarded-For from the right, skipping the trusted
+// proxies; the first other hop is the client. Entries left of it were written
+// by the client and are ignored.
+func resolveClientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	clientIP := remoteIP(r)
+	if !isTrustedProxy(clientIP, trustedProxies) {
+		return clientIP
+	}
+
+	if forwardedFor := strings.TrimSpace(strings.Join(r.Header.Values("X-Forwarded-For"), ",")); forwardedFor != "" {
+		hops := strings.Split(forwardedFor, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if _, err := netip.ParseAddr(hop); err != nil {
+				break
+			}
+
+			clientIP = hop
+			if !isTrustedProxy(hop, trustedProxies) {
+				break
+			}
+		}
+		return clientIP
+	}
+
+	if realIP := strings.TrimSpace(r.Header.Get("X-Real-Ip")); realIP != "" {
+		if _, err := netip.ParseAddr(realIP); err == nil {
+			return realIP
+		}
+	}
+
+	return clientIP
+}
+
+func isTrustedProxy(ipAddress string, trustedProxies []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ipAddress)
+	if err != nil {
+		return false
+	}
+
+	addr = addr.Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(strings.TrimSpace(r.RemoteAddr))
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
@@ -11,6 +11,9 @@ func CORSMiddleware(next http.Handler, corsCfg appconfig.CORSConfig) http.Handle
 		w.Header().Set("Access-Control-Allow-Origin", corsCfg.AllowOrigin)
 		w.Header().Set("Access-Control-Allow-Methods", corsCfg.AllowMethods)
 		w.Header().Set("Access-Control-Allow-Headers", corsCfg.AllowHeaders)
+		if corsCfg.AllowCredentials {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
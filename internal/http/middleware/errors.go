@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+
+	"admin.com/admin-api/internal/domain"
+	"admin.com/admin-api/internal/http/response"
+)
+
+// These codes mirror the httpErrors mappings; that package imports
+// middleware, so the envelopes are written through response directly.
+const (
+	unauthorizedCode    = "UNAUTHORIZED"
+	forbiddenCode       = "FORBIDDEN"
+	tooManyRequestsCode = "RATE_LIMITED"
+	internalCode        = "INTERNAL"
+)
+
+func writeUnauthorized(w http.ResponseWriter) {
+	response.WriteErrorWithCode(w, http.StatusUnauthorized, unauthorizedCode, domain.UnauthorizedMessage)
+}
+
+func writeInternal(w http.ResponseWriter) {
+	response.WriteErrorWithCode(w, http.StatusInternalServerError, internalCode, domain.InternalServerErrorMessage)
+}
+
+func writeForbidden(w http.ResponseWriter) {
+	response.WriteErrorWithCode(w, http.StatusForbidden, forbiddenCode, domain.ForbiddenMessage)
+}
+
+func writeTooManyRequests(w http.ResponseWriter) {
+	response.WriteErrorWithCode(w, http.StatusTooManyRequests, tooManyRequestsCode, domain.TooManyRequestsMessage)
+}
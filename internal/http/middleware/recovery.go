@@ -25,7 +25,7 @@ func RecoveryMiddleware(next http.Handler) http.Handler {
 					"request_id", requestID,
 					"method", r.Method,
 					"path", r.URL.Path,
-					"client_ip", clientIP(r),
+					"client_ip", ClientIP(r),
 					"panic", rec,
 					"stack_trace", string(debug.Stack()),
 				)
@@ -2,9 +2,7 @@ package middleware
 
 import (
 	"log/slog"
-	"net"
 	"net/http"
-	"strings"
 	"time"
 
 	appLogger "admin.com/admin-api/pkg/logger"
@@ -57,7 +55,7 @@ func RequestLoggingMiddleware(next http.Handler) http.Handler {
 				"status", recorder.status,
 				"duration_ms", time.Since(start).Milliseconds(),
 				"response_bytes", recorder.size,
-				"client_ip", clientIP(r),
+				"client_ip", ClientIP(r),
 			}
 
 			switch {
@@ -73,23 +71,3 @@ func RequestLoggingMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(recorder, r)
 	})
 }
-
-func clientIP(r *http.Request) string {
-	if forwardedFor := strings.TrimSpace(r.Header.Get("X-Forwarded-For")); forwardedFor != "" {
-		if idx := strings.Index(forwardedFor, ","); idx != -1 {
-			return strings.TrimSpace(forwardedFor[:idx])
-		}
-		return forwardedFor
-	}
-
-	if realIP := strings.TrimSpace(r.Header.Get("X-Real-Ip")); realIP != "" {
-		return realIP
-	}
-
-	host, _, err := net.SplitHostPort(strings.TrimSpace(r.RemoteAddr))
-	if err != nil {
-		return r.RemoteAddr
-	}
-
-	return host
-}
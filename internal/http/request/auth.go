@@ -13,3 +13,45 @@ type LoginInput struct {
 	Identity string `json:"identity"`
 	Password string `json:"password"`
 }
+
+type VerifyEmailInput struct {
+	Token string `json:"token"`
+}
+
+type ResendVerificationInput struct {
+	Email string `json:"email"`
+}
+
+type ForgotPasswordInput struct {
+	Email string `json:"email"`
+}
+
+type SetupTOTPInput struct {
+	CurrentPassword string `json:"currentPassword"`
+}
+
+type VerifyTOTPInput struct {
+	Code string `json:"code"`
+}
+
+type MFAChallengeInput struct {
+	ChallengeToken string `json:"challengeToken"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recoveryCode"`
+}
+
+type ChangePasswordInput struct {
+	CurrentPassword     string `json:"currentPassword"`
+	NewPassword         string `json:"newPassword"`
+	RevokeOtherSessions bool   `json:"revokeOtherSessions"`
+}
+
+type ResetPasswordInput struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+type AcceptInvitationInput struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
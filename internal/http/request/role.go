@@ -0,0 +1,11 @@
+package request
+
+type CreateRoleInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type UpdateRoleInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
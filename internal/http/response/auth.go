@@ -4,6 +4,7 @@ import (
 	"time"
 
 	authusecase "admin.com/admin-api/internal/usecase/auth"
+	"github.com/google/uuid"
 )
 
 type SessionOutput struct {
@@ -24,15 +25,79 @@ func FromAuthSession(session authusecase.SessionOutput) SessionOutput {
 	}
 }
 
+// MFAChallengeOutput answers a login that needs a second factor; clients post
+// ChallengeToken to /auth/mfa/challenge with the code.
+type MFAChallengeOutput struct {
+	MFARequired    bool      `json:"mfaRequired"`
+	ChallengeToken string    `json:"challengeToken"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+}
+
+func FromMFAChallenge(challenge authusecase.MFAChallengeOutput) MFAChallengeOutput {
+	return MFAChallengeOutput{
+		MFARequired:    true,
+		ChallengeToken: challenge.ChallengeToken,
+		ExpiresAt:      challenge.ExpiresAt,
+	}
+}
+
+type TOTPSetupOutput struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauthUri"`
+}
+
+func FromTOTPSetup(setup authusecase.TOTPSetupOutput) TOTPSetupOutput {
+	return TOTPSetupOutput{
+		Secret:     setup.Secret,
+		OTPAuthURI: setup.URI,
+	}
+}
+
+type RecoveryCodesOutput struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
 func FromAuthUser(user authusecase.UserOutput) UserOutput {
 	return UserOutput{
-		ID:        user.ID,
-		Name:      user.Name,
-		LastName:  user.LastName,
-		Username:  user.Username,
-		Email:     user.Email,
-		Avatar:    user.Avatar,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:                 user.ID,
+		Name:               user.Name,
+		LastName:           user.LastName,
+		Username:           user.Username,
+		Email:              user.Email,
+		EmailVerifiedAt:    user.EmailVerifiedAt,
+		MustChangePassword: user.MustChangePassword,
+		Avatar:             user.Avatar,
+		Status:             user.Status,
+		CreatedAt:          user.CreatedAt,
+		UpdatedAt:          user.UpdatedAt,
 	}
 }
+
+type ActiveSessionOutput struct {
+	FamilyID    uuid.UUID `json:"familyId"`
+	StartedAt   time.Time `json:"startedAt"`
+	LastUsedAt  time.Time `json:"lastUsedAt"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+	UserAgent   string    `json:"userAgent"`
+	IPAddress   string    `json:"ipAddress"`
+	DeviceLabel string    `json:"device"`
+	Current     bool      `json:"current"`
+}
+
+func FromActiveSessions(sessions []authusecase.ActiveSessionOutput) []ActiveSessionOutput {
+	output := make([]ActiveSessionOutput, 0, len(sessions))
+	for _, session := range sessions {
+		output = append(output, ActiveSessionOutput{
+			FamilyID:    session.FamilyID,
+			StartedAt:   session.StartedAt,
+			LastUsedAt:  session.LastUsedAt,
+			ExpiresAt:   session.ExpiresAt,
+			UserAgent:   session.UserAgent,
+			IPAddress:   session.IPAddress,
+			DeviceLabel: session.DeviceLabel,
+			Current:     session.Current,
+		})
+	}
+
+	return output
+}
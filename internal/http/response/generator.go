@@ -18,6 +18,15 @@ func WriteSuccess(w http.ResponseWriter, status int, body any) {
 	})
 }
 
+func WriteSuccessWithMeta(w http.ResponseWriter, status int, body any, meta any) {
+	writeJSON(w, status, SuccessResponse{
+		Success: true,
+		Data:    body,
+		Meta:    meta,
+		Status:  status,
+	})
+}
+
 func WriteError(w http.ResponseWriter, status int, errMsg string) {
 	WriteErrorWithCode(w, status, "", errMsg)
 }
@@ -31,6 +40,11 @@ func WriteErrorWithCode(w http.ResponseWriter, status int, code string, errMsg s
 	})
 }
 
+// WriteJSON writes payload as is, for documents with a format of their own.
+func WriteJSON(w http.ResponseWriter, status int, payload any) {
+	writeJSON(w, status, payload)
+}
+
 func writeJSON(w http.ResponseWriter, status int, payload any) {
 	body, err := json.Marshal(payload)
 	if err != nil {
@@ -0,0 +1,38 @@
+package response
+
+import domainauth "admin.com/admin-api/internal/domain/auth"
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid,omitempty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+func FromPublicKeys(keys []domainauth.PublicKey) JWKSet {
+	set := JWKSet{Keys: make([]JWK, 0, len(keys))}
+	for _, key := range keys {
+		set.Keys = append(set.Keys, JWK{
+			KeyType:   key.KeyType,
+			KeyID:     key.KeyID,
+			Use:       "sig",
+			Algorithm: key.Algorithm,
+			Curve:     key.Curve,
+			N:         key.N,
+			E:         key.E,
+			X:         key.X,
+			Y:         key.Y,
+		})
+	}
+
+	return set
+}
@@ -0,0 +1,26 @@
+package response
+
+import (
+	"time"
+
+	roleusecase "admin.com/admin-api/internal/usecase/role"
+	"github.com/google/uuid"
+)
+
+type RoleOutput struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+func FromRole(role roleusecase.RoleOutput) RoleOutput {
+	return RoleOutput{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
@@ -3,5 +3,12 @@ package response
 type SuccessResponse struct {
 	Success bool `json:"success"`
 	Data    any  `json:"data,omitempty"`
+	Meta    any  `json:"meta,omitempty"`
 	Status  int  `json:"status"`
 }
+
+type PageMeta struct {
+	NextCursor *string `json:"nextCursor"`
+	Total      int     `json:"total"`
+	Limit      int     `json:"limit"`
+}
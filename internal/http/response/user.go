@@ -8,25 +8,66 @@ import (
 )
 
 type UserOutput struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	LastName  string    `json:"lastName"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	Avatar    string    `json:"avatar"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID                 uuid.UUID  `json:"id"`
+	Name               string     `json:"name"`
+	LastName           string     `json:"lastName"`
+	Username           string     `json:"username"`
+	Email              string     `json:"email"`
+	EmailVerifiedAt    *time.Time `json:"emailVerifiedAt"`
+	MustChangePassword bool       `json:"mustChangePassword"`
+	Avatar             string     `json:"avatar"`
+	Status             string     `json:"status"`
+	CreatedAt          time.Time  `json:"createdAt"`
+	UpdatedAt          time.Time  `json:"updatedAt"`
+}
+
+type UserSearchOutput struct {
+	UserOutput
+	Score float64 `json:"score"`
 }
 
 func FromUser(user userusecase.UserOutput) UserOutput {
 	return UserOutput{
-		ID:        user.ID,
-		Name:      user.Name,
-		LastName:  user.LastName,
-		Username:  user.Username,
-		Email:     user.Email,
-		Avatar:    user.Avatar,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:                 user.ID,
+		Name:               user.Name,
+		LastName:           user.LastName,
+		Username:           user.Username,
+		Email:              user.Email,
+		EmailVerifiedAt:    user.EmailVerifiedAt,
+		MustChangePassword: user.MustChangePassword,
+		Avatar:             user.Avatar,
+		Status:             user.Status,
+		CreatedAt:          user.CreatedAt,
+		UpdatedAt:          user.UpdatedAt,
+	}
+}
+
+func FromUserPage(page userusecase.UserPageOutput) ([]UserOutput, PageMeta) {
+	userOutputs := make([]UserOutput, len(page.Users))
+	for i, user := range page.Users {
+		userOutputs[i] = FromUser(user)
+	}
+
+	meta := PageMeta{
+		Total: page.Total,
+		Limit: page.Limit,
 	}
+	if page.NextCursor != "" {
+		nextCursor := page.NextCursor
+		meta.NextCursor = &nextCursor
+	}
+
+	return userOutputs, meta
+}
+
+func FromUserSearch(results []userusecase.UserSearchOutput) []UserSearchOutput {
+	searchOutputs := make([]UserSearchOutput, len(results))
+	for i, result := range results {
+		searchOutputs[i] = UserSearchOutput{
+			UserOutput: FromUser(result.User),
+			Score:      result.Score,
+		}
+	}
+
+	return searchOutputs
 }
@@ -0,0 +1,55 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	domainmail "admin.com/admin-api/internal/domain/mail"
+)
+
+// FileMailer writes every email as a plain-text file in dir, newest last when
+// sorted by name, so tests can read the tokens back.
+type FileMailer struct {
+	dir  string
+	from string
+	now  func() time.Time
+	seq  atomic.Uint64
+}
+
+func NewFileMailer(dir string, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create mail directory: %w", err)
+	}
+
+	return &FileMailer{dir: dir, from: from, now: time.Now}, nil
+}
+
+func (m *FileMailer) Send(_ context.Context, message domainmail.Message) error {
+	sentAt := m.now().UTC()
+	name := fmt.Sprintf("%s-%06d-%s.eml", sentAt.Format("20060102T150405.000000000Z"), m.seq.Add(1), fileSafe(message.To))
+
+	content := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\n\r\n%s\r\n",
+		m.from, message.To, message.Subject, sentAt.Format(time.RFC1123Z), message.Body)
+
+	if err := os.WriteFile(filepath.Join(m.dir, name), []byte(content), 0o600); err != nil {
+		return fmt.Errorf("write mail file: %w", err)
+	}
+
+	return nil
+}
+
+func fileSafe(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_', r == '@':
+			return r
+		default:
+			return '_'
+		}
+	}, value)
+}
@@ -0,0 +1,132 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+
+	domainmail "admin.com/admin-api/internal/domain/mail"
+)
+
+// smtpTimeout bounds a delivery when the caller's context has no deadline.
+const smtpTimeout = 30 * time.Second
+
+// SMTPMailer delivers emails through an SMTP relay. The connection is upgraded
+// with STARTTLS whenever the server offers it, and credentials are never sent
+// over a plain connection except to localhost.
+type SMTPMailer struct {
+	address  string
+	host     string
+	from     string
+	sender   string
+	username string
+	password string
+	now      func() time.Time
+}
+
+func NewSMTPMailer(host string, port string, from string, username string, password string) (*SMTPMailer, error) {
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("parse mail sender: %w", err)
+	}
+
+	return &SMTPMailer{
+		address:  net.JoinHostPort(host, port),
+		host:     host,
+		from:     sender.String(),
+		sender:   sender.Address,
+		username: username,
+		password: password,
+		now:      time.Now,
+	}, nil
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, message domainmail.Message) error {
+	content, err := m.compose(message)
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", m.address)
+	if err != nil {
+		return fmt.Errorf("smtp dial: %w", err)
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = m.now().Add(smtpTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("smtp dial: %w", err)
+	}
+
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("smtp greeting: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.host, MinVersion: tls.VersionTLS12}); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+	if m.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.username, m.password, m.host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	if err := client.Mail(m.sender); err != nil {
+		return fmt.Errorf("smtp sender: %w", err)
+	}
+	if err := client.Rcpt(message.To); err != nil {
+		return fmt.Errorf("smtp recipient: %w", err)
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if _, err := writer.Write(content); err != nil {
+		_ = writer.Close()
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+
+	return client.Quit()
+}
+
+func (m *SMTPMailer) compose(message domainmail.Message) ([]byte, error) {
+	recipient, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return nil, fmt.Errorf("parse mail recipient: %w", err)
+	}
+
+	var content bytes.Buffer
+	fmt.Fprintf(&content, "From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\n",
+		m.from, recipient.String(), mime.QEncoding.Encode("utf-8", message.Subject), m.now().UTC().Format(time.RFC1123Z))
+	content.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\nContent-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	body := quotedprintable.NewWriter(&content)
+	if _, err := body.Write([]byte(message.Body)); err != nil {
+		return nil, fmt.Errorf("encode mail body: %w", err)
+	}
+	if err := body.Close(); err != nil {
+		return nil, fmt.Errorf("encode mail body: %w", err)
+	}
+
+	return content.Bytes(), nil
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	pgroot "admin.com/admin-api/internal/repository/postgres"
+	"github.com/uptrace/bun"
+)
+
+type AccessTokenDenylist struct {
+	dbConn *bun.DB
+	now    func() time.Time
+}
+
+func NewAccessTokenDenylist(dbConn *bun.DB, now func() time.Time) *AccessTokenDenylist {
+	if now == nil {
+		now = time.Now
+	}
+
+	return &AccessTokenDenylist{
+		dbConn: dbConn,
+		now:    now,
+	}
+}
+
+// Deny also prunes expired entries; logouts are rare enough that this keeps
+// the table small without a separate cleanup job.
+func (repo *AccessTokenDenylist) Deny(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	now := repo.now().UTC()
+
+	return repo.dbConn.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewDelete().Model((*DBDeniedAccessToken)(nil)).Where("expires_at <= ?", now).Exec(ctx); err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		if !expiresAt.After(now) {
+			return nil
+		}
+
+		model := &DBDeniedAccessToken{
+			TokenID:   tokenID,
+			ExpiresAt: expiresAt.UTC(),
+		}
+		_, err := tx.NewInsert().
+			Model(model).
+			On("CONFLICT (token_id) DO UPDATE").
+			Set("expires_at = GREATEST(aatd.expires_at, EXCLUDED.expires_at)").
+			Exec(ctx)
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		return nil
+	})
+}
+
+func (repo *AccessTokenDenylist) IsDenied(ctx context.Context, tokenID string) (bool, error) {
+	exists, err := repo.dbConn.NewSelect().
+		Model((*DBDeniedAccessToken)(nil)).
+		Where("token_id = ?", tokenID).
+		Where("expires_at > ?", repo.now().UTC()).
+		Exists(ctx)
+	if err != nil {
+		return false, pgroot.WrapInternal(err)
+	}
+
+	return exists, nil
+}
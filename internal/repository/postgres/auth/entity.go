@@ -10,12 +10,80 @@ import (
 type DBRefreshToken struct {
 	bun.BaseModel `bun:"table:auth_refresh_tokens,alias:art"`
 
-	ID         uuid.UUID  `bun:"id,pk,type:uuid,default:gen_random_uuid()"`
-	UserID     uuid.UUID  `bun:"user_id,type:uuid,notnull"`
-	FamilyID   uuid.UUID  `bun:"family_id,type:uuid,notnull"`
-	TokenHash  string     `bun:"token_hash,notnull"`
-	ExpiresAt  time.Time  `bun:"expires_at,notnull"`
-	RevokedAt  *time.Time `bun:"revoked_at"`
-	LastUsedAt *time.Time `bun:"last_used_at"`
-	CreatedAt  time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	ID           uuid.UUID  `bun:"id,pk,type:uuid,default:gen_random_uuid()"`
+	UserID       uuid.UUID  `bun:"user_id,type:uuid,notnull"`
+	FamilyID     uuid.UUID  `bun:"family_id,type:uuid,notnull"`
+	TokenHash    string     `bun:"token_hash,notnull"`
+	ExpiresAt    time.Time  `bun:"expires_at,notnull"`
+	RevokedAt    *time.Time `bun:"revoked_at"`
+	LastUsedAt   *time.Time `bun:"last_used_at"`
+	ReplacedByID *uuid.UUID `bun:"replaced_by_id,type:uuid"`
+	UserAgent    string     `bun:"user_agent,nullzero"`
+	IPAddress    string     `bun:"ip_address,nullzero"`
+	DeviceLabel  string     `bun:"device_label,nullzero"`
+	CreatedAt    time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+}
+
+type DBSession struct {
+	bun.BaseModel `bun:"table:auth_refresh_tokens,alias:art"`
+
+	FamilyID    uuid.UUID `bun:"family_id,type:uuid"`
+	UserID      uuid.UUID `bun:"user_id,type:uuid"`
+	StartedAt   time.Time `bun:"started_at"`
+	LastUsedAt  time.Time `bun:"last_used_at"`
+	ExpiresAt   time.Time `bun:"expires_at"`
+	UserAgent   string    `bun:"user_agent"`
+	IPAddress   string    `bun:"ip_address"`
+	DeviceLabel string    `bun:"device_label"`
+}
+
+type DBDeniedAccessToken struct {
+	bun.BaseModel `bun:"table:auth_access_token_denylist,alias:aatd"`
+
+	TokenID   string    `bun:"token_id,pk"`
+	ExpiresAt time.Time `bun:"expires_at,notnull"`
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+}
+
+type DBLoginAttempt struct {
+	bun.BaseModel `bun:"table:auth_login_attempts,alias:ala"`
+
+	ThrottleKey     string     `bun:"throttle_key,pk"`
+	Failures        int        `bun:"failures,notnull"`
+	WindowStartedAt time.Time  `bun:"window_started_at,notnull"`
+	Lockouts        int        `bun:"lockouts,notnull"`
+	LockedUntil     *time.Time `bun:"locked_until"`
+	UpdatedAt       time.Time  `bun:"updated_at,notnull"`
+}
+
+type DBUserToken struct {
+	bun.BaseModel `bun:"table:user_tokens,alias:ut"`
+
+	ID        uuid.UUID  `bun:"id,pk,type:uuid,default:gen_random_uuid()"`
+	UserID    uuid.UUID  `bun:"user_id,type:uuid,notnull"`
+	Purpose   string     `bun:"purpose,notnull"`
+	TokenHash string     `bun:"token_hash,notnull"`
+	ExpiresAt time.Time  `bun:"expires_at,notnull"`
+	UsedAt    *time.Time `bun:"used_at"`
+	CreatedAt time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+}
+
+type DBTOTPCredential struct {
+	bun.BaseModel `bun:"table:user_totp_credentials,alias:utc"`
+
+	UserID           uuid.UUID  `bun:"user_id,pk,type:uuid"`
+	SecretCiphertext string     `bun:"secret_ciphertext,notnull"`
+	ConfirmedAt      *time.Time `bun:"confirmed_at"`
+	LastUsedStep     int64      `bun:"last_used_step,notnull"`
+	CreatedAt        time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+}
+
+type DBRecoveryCode struct {
+	bun.BaseModel `bun:"table:user_recovery_codes,alias:urc"`
+
+	ID        uuid.UUID  `bun:"id,pk,type:uuid,default:gen_random_uuid()"`
+	UserID    uuid.UUID  `bun:"user_id,type:uuid,notnull"`
+	CodeHash  string     `bun:"code_hash,notnull"`
+	UsedAt    *time.Time `bun:"used_at"`
+	CreatedAt time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
 }
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	domainauth "admin.com/admin-api/internal/domain/auth"
+	pgroot "admin.com/admin-api/internal/repository/postgres"
+	"github.com/uptrace/bun"
+)
+
+// LoginAttemptStore shares the login throttle counters between every API
+// instance.
+type LoginAttemptStore struct {
+	dbConn *bun.DB
+}
+
+func NewLoginAttemptStore(dbConn *bun.DB) *LoginAttemptStore {
+	return &LoginAttemptStore{
+		dbConn: dbConn,
+	}
+}
+
+func (repo *LoginAttemptStore) Get(ctx context.Context, key string) (domainauth.LoginAttempt, error) {
+	model := new(DBLoginAttempt)
+	if err := repo.dbConn.NewSelect().Model(model).Where("throttle_key = ?", key).Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domainauth.LoginAttempt{Key: key}, nil
+		}
+		return domainauth.LoginAttempt{}, pgroot.WrapInternal(err)
+	}
+
+	return toDomainLoginAttempt(model), nil
+}
+
+// RecordFailure counts in a single upsert so concurrent attempts cannot lose
+// failures, and prunes idle entries on the way like the access token denylist.
+func (repo *LoginAttemptStore) RecordFailure(ctx context.Context, key string, at time.Time, policy domainauth.LoginThrottlePolicy) (domainauth.LoginAttempt, error) {
+	at = at.UTC()
+	windowStartedBefore := at.Add(-policy.Window)
+	forgetBefore := at.Add(-policy.Retention())
+	model := &DBLoginAttempt{
+		ThrottleKey:     key,
+		Failures:        1,
+		WindowStartedAt: at,
+		UpdatedAt:       at,
+	}
+
+	err := repo.dbConn.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewDelete().
+			Model((*DBLoginAttempt)(nil)).
+			Where("updated_at < ?", forgetBefore).
+			Where("locked_until IS NULL OR locked_until <= ?", at).
+			Exec(ctx)
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		err = tx.NewInsert().
+			Model(model).
+			On("CONFLICT (throttle_key) DO UPDATE").
+			Set("failures = CASE WHEN ala.window_started_at <= ? THEN 1 ELSE ala.failures + 1 END", windowStartedBefore).
+			Set("window_started_at = CASE WHEN ala.window_started_at <= ? THEN EXCLUDED.window_started_at ELSE ala.window_started_at END", windowStartedBefore).
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("*").
+			Scan(ctx)
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domainauth.LoginAttempt{}, err
+	}
+
+	return toDomainLoginAttempt(model), nil
+}
+
+func (repo *LoginAttemptStore) Lock(ctx context.Context, key string, at time.Time, until time.Time) error {
+	_, err := repo.dbConn.NewUpdate().
+		Model((*DBLoginAttempt)(nil)).
+		Set("failures = 0").
+		Set("window_started_at = ?", at.UTC()).
+		Set("lockouts = lockouts + 1").
+		Set("locked_until = ?", until.UTC()).
+		Set("updated_at = ?", at.UTC()).
+		Where("throttle_key = ?", key).
+		Exec(ctx)
+	if err != nil {
+		return pgroot.WrapInternal(err)
+	}
+
+	return nil
+}
+
+func (repo *LoginAttemptStore) Reset(ctx context.Context, key string) error {
+	if _, err := repo.dbConn.NewDelete().Model((*DBLoginAttempt)(nil)).Where("throttle_key = ?", key).Exec(ctx); err != nil {
+		return pgroot.WrapInternal(err)
+	}
+
+	return nil
+}
@@ -4,27 +4,33 @@ import domainauth "admin.com/admin-api/internal/domain/auth"
 
 func toDomainRefreshToken(model *DBRefreshToken) *domainauth.RefreshToken {
 	return &domainauth.RefreshToken{
-		ID:         model.ID,
-		UserID:     model.UserID,
-		FamilyID:   model.FamilyID,
-		TokenHash:  model.TokenHash,
-		ExpiresAt:  model.ExpiresAt,
-		RevokedAt:  model.RevokedAt,
-		LastUsedAt: model.LastUsedAt,
-		CreatedAt:  model.CreatedAt,
+		ID:           model.ID,
+		UserID:       model.UserID,
+		FamilyID:     model.FamilyID,
+		TokenHash:    model.TokenHash,
+		ExpiresAt:    model.ExpiresAt,
+		RevokedAt:    model.RevokedAt,
+		LastUsedAt:   model.LastUsedAt,
+		ReplacedByID: model.ReplacedByID,
+		Client:       toDomainClientMetadata(model.UserAgent, model.IPAddress, model.DeviceLabel),
+		CreatedAt:    model.CreatedAt,
 	}
 }
 
 func fromDomainRefreshToken(model *domainauth.RefreshToken) *DBRefreshToken {
 	return &DBRefreshToken{
-		ID:         model.ID,
-		UserID:     model.UserID,
-		FamilyID:   model.FamilyID,
-		TokenHash:  model.TokenHash,
-		ExpiresAt:  model.ExpiresAt,
-		RevokedAt:  model.RevokedAt,
-		LastUsedAt: model.LastUsedAt,
-		CreatedAt:  model.CreatedAt,
+		ID:           model.ID,
+		UserID:       model.UserID,
+		FamilyID:     model.FamilyID,
+		TokenHash:    model.TokenHash,
+		ExpiresAt:    model.ExpiresAt,
+		RevokedAt:    model.RevokedAt,
+		LastUsedAt:   model.LastUsedAt,
+		ReplacedByID: model.ReplacedByID,
+		UserAgent:    model.Client.UserAgent,
+		IPAddress:    model.Client.IPAddress,
+		DeviceLabel:  model.Client.DeviceLabel,
+		CreatedAt:    model.CreatedAt,
 	}
 }
 
@@ -36,5 +42,84 @@ func syncDomainRefreshTokenFromModel(dst *domainauth.RefreshToken, src *DBRefres
 	dst.ExpiresAt = src.ExpiresAt
 	dst.RevokedAt = src.RevokedAt
 	dst.LastUsedAt = src.LastUsedAt
+	dst.ReplacedByID = src.ReplacedByID
+	dst.Client = toDomainClientMetadata(src.UserAgent, src.IPAddress, src.DeviceLabel)
 	dst.CreatedAt = src.CreatedAt
 }
+
+func toDomainSessions(models []DBSession) []domainauth.Session {
+	sessions := make([]domainauth.Session, 0, len(models))
+	for _, model := range models {
+		sessions = append(sessions, domainauth.Session{
+			FamilyID:   model.FamilyID,
+			UserID:     model.UserID,
+			StartedAt:  model.StartedAt,
+			LastUsedAt: model.LastUsedAt,
+			ExpiresAt:  model.ExpiresAt,
+			Client:     toDomainClientMetadata(model.UserAgent, model.IPAddress, model.DeviceLabel),
+		})
+	}
+
+	return sessions
+}
+
+func toDomainClientMetadata(userAgent string, ipAddress string, deviceLabel string) domainauth.ClientMetadata {
+	return domainauth.ClientMetadata{
+		UserAgent:   userAgent,
+		IPAddress:   ipAddress,
+		DeviceLabel: deviceLabel,
+	}
+}
+
+func toDomainLoginAttempt(model *DBLoginAttempt) domainauth.LoginAttempt {
+	attempt := domainauth.LoginAttempt{
+		Key:             model.ThrottleKey,
+		Failures:        model.Failures,
+		WindowStartedAt: model.WindowStartedAt,
+		Lockouts:        model.Lockouts,
+		UpdatedAt:       model.UpdatedAt,
+	}
+	if model.LockedUntil != nil {
+		attempt.LockedUntil = *model.LockedUntil
+	}
+
+	return attempt
+}
+
+func fromDomainUserToken(token *domainauth.UserToken) *DBUserToken {
+	return &DBUserToken{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		Purpose:   string(token.Purpose),
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		UsedAt:    token.UsedAt,
+		CreatedAt: token.CreatedAt,
+	}
+}
+
+func syncDomainUserTokenFromModel(dst *domainauth.UserToken, src *DBUserToken) {
+	dst.ID = src.ID
+	dst.UserID = src.UserID
+	dst.Purpose = domainauth.UserTokenPurpose(src.Purpose)
+	dst.TokenHash = src.TokenHash
+	dst.ExpiresAt = src.ExpiresAt
+	dst.UsedAt = src.UsedAt
+	dst.CreatedAt = src.CreatedAt
+}
+
+func toDomainUserToken(model *DBUserToken) *domainauth.UserToken {
+	token := &domainauth.UserToken{}
+	syncDomainUserTokenFromModel(token, model)
+	return token
+}
+
+func toDomainTOTPCredential(model *DBTOTPCredential) *domainauth.TOTPCredential {
+	return &domainauth.TOTPCredential{
+		UserID:           model.UserID,
+		SecretCiphertext: model.SecretCiphertext,
+		ConfirmedAt:      model.ConfirmedAt,
+		LastUsedStep:     model.LastUsedStep,
+		CreatedAt:        model.CreatedAt,
+	}
+}
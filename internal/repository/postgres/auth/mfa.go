@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"admin.com/admin-api/internal/domain"
+	domainauth "admin.com/admin-api/internal/domain/auth"
+	pgroot "admin.com/admin-api/internal/repository/postgres"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+func (repo *AuthRepository) GetTOTPCredential(ctx context.Context, userID uuid.UUID) (*domainauth.TOTPCredential, error) {
+	model := new(DBTOTPCredential)
+	if err := repo.dbConn.NewSelect().Model(model).Where("user_id = ?", userID).Scan(ctx); err != nil {
+		return nil, pgroot.MapSelectError(err)
+	}
+
+	return toDomainTOTPCredential(model), nil
+}
+
+// SaveTOTPCredential only overwrites a pending credential, so a second setup
+// cannot silently replace the authenticator of an enrolled user.
+func (repo *AuthRepository) SaveTOTPCredential(ctx context.Context, credential *domainauth.TOTPCredential) error {
+	model := &DBTOTPCredential{
+		UserID:           credential.UserID,
+		SecretCiphertext: credential.SecretCiphertext,
+	}
+
+	res, err := repo.dbConn.NewInsert().
+		Model(model).
+		On("CONFLICT (user_id) DO UPDATE").
+		Set("secret_ciphertext = EXCLUDED.secret_ciphertext").
+		Set("last_used_step = 0").
+		Set("created_at = current_timestamp").
+		Where("utc.confirmed_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return pgroot.WrapInternal(err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return pgroot.WrapInternal(err)
+	}
+
+	if rows == 0 {
+		return domain.ErrMFAAlreadyEnabled
+	}
+
+	return nil
+}
+
+func (repo *AuthRepository) ConfirmTOTPCredential(ctx context.Context, userID uuid.UUID, step int64, confirmedAt time.Time, recoveryCodeHashes []string) error {
+	return repo.dbConn.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewUpdate().
+			Model((*DBTOTPCredential)(nil)).
+			Set("confirmed_at = ?", confirmedAt).
+			Set("last_used_step = ?", step).
+			Where("user_id = ?", userID).
+			Where("confirmed_at IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		if rows == 0 {
+			return domain.ErrMFAAlreadyEnabled
+		}
+
+		if _, err := tx.NewDelete().Model((*DBRecoveryCode)(nil)).Where("user_id = ?", userID).Exec(ctx); err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		codes := make([]DBRecoveryCode, len(recoveryCodeHashes))
+		for i, codeHash := range recoveryCodeHashes {
+			codes[i] = DBRecoveryCode{UserID: userID, CodeHash: codeHash}
+		}
+
+		if len(codes) > 0 {
+			if _, err := tx.NewInsert().Model(&codes).Exec(ctx); err != nil {
+				return pgroot.WrapInternal(err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// UseTOTPStep moves last_used_step forward in the statement that checks it,
+// so the same code cannot complete two logins.
+func (repo *AuthRepository) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	res, err := repo.dbConn.NewUpdate().
+		Model((*DBTOTPCredential)(nil)).
+		Set("last_used_step = ?", step).
+		Where("user_id = ?", userID).
+		Where("confirmed_at IS NOT NULL").
+		Where("last_used_step < ?", step).
+		Exec(ctx)
+	if err != nil {
+		return pgroot.WrapInternal(err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return pgroot.WrapInternal(err)
+	}
+
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *AuthRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, usedAt time.Time) (int, error) {
+	remaining := 0
+
+	err := repo.dbConn.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewUpdate().
+			Model((*DBRecoveryCode)(nil)).
+			Set("used_at = ?", usedAt).
+			Where("user_id = ?", userID).
+			Where("code_hash = ?", codeHash).
+			Where("used_at IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		if rows == 0 {
+			return domain.ErrNotFound
+		}
+
+		remaining, err = tx.NewSelect().
+			Model((*DBRecoveryCode)(nil)).
+			Where("user_id = ?", userID).
+			Where("used_at IS NULL").
+			Count(ctx)
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return remaining, nil
+}
@@ -6,8 +6,10 @@ import (
 
 	"admin.com/admin-api/internal/domain"
 	domainauth "admin.com/admin-api/internal/domain/auth"
+	roledomain "admin.com/admin-api/internal/domain/role"
 	userdomain "admin.com/admin-api/internal/domain/user"
 	pgroot "admin.com/admin-api/internal/repository/postgres"
+	rolepostgres "admin.com/admin-api/internal/repository/postgres/role"
 	userpostgres "admin.com/admin-api/internal/repository/postgres/user"
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
@@ -39,6 +41,19 @@ func (repo *AuthRepository) GetUserByIdentity(ctx context.Context, identity stri
 	return userpostgres.GetUserByIdentity(ctx, repo.dbConn, identity)
 }
 
+func (repo *AuthRepository) GetUserRoleNames(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	roles, err := rolepostgres.GetUserRoles(ctx, repo.dbConn, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return roledomain.Names(roles), nil
+}
+
+func (repo *AuthRepository) GetUserPermissions(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	return rolepostgres.GetUserPermissions(ctx, repo.dbConn, userID)
+}
+
 func (repo *AuthRepository) CreateRefreshToken(ctx context.Context, token *domainauth.RefreshToken) error {
 	model := fromDomainRefreshToken(token)
 	if _, err := repo.dbConn.NewInsert().Model(model).Exec(ctx); err != nil {
@@ -58,14 +73,21 @@ func (repo *AuthRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash
 	return toDomainRefreshToken(model), nil
 }
 
+// RotateRefreshToken inserts the next token first so the current one can point
+// at it through replaced_by_id; that link is what reuse detection relies on.
 func (repo *AuthRepository) RotateRefreshToken(ctx context.Context, currentTokenID uuid.UUID, nextToken *domainauth.RefreshToken, usedAt time.Time) error {
 	nextTokenModel := fromDomainRefreshToken(nextToken)
 
 	err := repo.dbConn.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(nextTokenModel).Exec(ctx); err != nil {
+			return pgroot.MapPersistenceWriteError(err, mapAuthUniqueConstraint)
+		}
+
 		res, err := tx.NewUpdate().
 			Model((*DBRefreshToken)(nil)).
 			Set("revoked_at = ?", usedAt).
 			Set("last_used_at = ?", usedAt).
+			Set("replaced_by_id = ?", nextTokenModel.ID).
 			Where("id = ?", currentTokenID).
 			Where("revoked_at IS NULL").
 			Exec(ctx)
@@ -82,10 +104,6 @@ func (repo *AuthRepository) RotateRefreshToken(ctx context.Context, currentToken
 			return domain.ErrConflict
 		}
 
-		if _, err := tx.NewInsert().Model(nextTokenModel).Exec(ctx); err != nil {
-			return pgroot.MapPersistenceWriteError(err, mapAuthUniqueConstraint)
-		}
-
 		return nil
 	})
 	if err != nil {
@@ -111,9 +129,187 @@ func (repo *AuthRepository) RevokeRefreshTokenByHash(ctx context.Context, tokenH
 	return nil
 }
 
+func (repo *AuthRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID, revokedAt time.Time) (int, error) {
+	res, err := repo.dbConn.NewUpdate().
+		Model((*DBRefreshToken)(nil)).
+		Set("revoked_at = ?", revokedAt).
+		Where("family_id = ?", familyID).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return 0, pgroot.WrapInternal(err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, pgroot.WrapInternal(err)
+	}
+
+	return int(rows), nil
+}
+
+// GetActiveSessions returns one row per family: only the latest token of a
+// family is unrevoked, and it carries the family's last activity.
+func (repo *AuthRepository) GetActiveSessions(ctx context.Context, userID uuid.UUID, now time.Time) ([]domainauth.Session, error) {
+	var sessions []DBSession
+
+	err := repo.dbConn.NewSelect().
+		Model(&sessions).
+		ColumnExpr("art.family_id, art.user_id, art.expires_at, art.user_agent, art.ip_address, art.device_label").
+		ColumnExpr("COALESCE(art.last_used_at, art.created_at) AS last_used_at").
+		ColumnExpr("(SELECT min(f.created_at) FROM auth_refresh_tokens AS f WHERE f.family_id = art.family_id) AS started_at").
+		Where("art.user_id = ?", userID).
+		Where("art.revoked_at IS NULL").
+		Where("art.expires_at > ?", now).
+		OrderExpr("last_used_at DESC, art.family_id ASC").
+		Scan(ctx)
+	if err != nil {
+		return []domainauth.Session{}, pgroot.WrapInternal(err)
+	}
+
+	return toDomainSessions(sessions), nil
+}
+
+func (repo *AuthRepository) RevokeUserSession(ctx context.Context, userID uuid.UUID, familyID uuid.UUID, revokedAt time.Time) error {
+	res, err := repo.dbConn.NewUpdate().
+		Model((*DBRefreshToken)(nil)).
+		Set("revoked_at = ?", revokedAt).
+		Where("user_id = ?", userID).
+		Where("family_id = ?", familyID).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return pgroot.WrapInternal(err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return pgroot.WrapInternal(err)
+	}
+
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *AuthRepository) RevokeUserSessions(ctx context.Context, userID uuid.UUID, revokedAt time.Time) (int, error) {
+	return revokeUserRefreshTokens(ctx, repo.dbConn, userID, revokedAt)
+}
+
+func revokeUserRefreshTokens(ctx context.Context, db bun.IDB, userID uuid.UUID, revokedAt time.Time) (int, error) {
+	res, err := db.NewUpdate().
+		Model((*DBRefreshToken)(nil)).
+		Set("revoked_at = ?", revokedAt).
+		Where("user_id = ?", userID).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return 0, pgroot.WrapInternal(err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, pgroot.WrapInternal(err)
+	}
+
+	return int(rows), nil
+}
+
+// RevokeUserAccess also moves users.tokens_valid_after forward, which turns
+// every access token issued so far into a rejected one.
+func (repo *AuthRepository) RevokeUserAccess(ctx context.Context, userID uuid.UUID, revokedAt time.Time) (int, error) {
+	revoked := 0
+
+	err := repo.dbConn.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewUpdate().
+			Model((*userpostgres.DBUser)(nil)).
+			Set("tokens_valid_after = ?", revokedAt).
+			Where("id = ?", userID).
+			Where("deleted_at IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		if rows == 0 {
+			return domain.ErrNotFound
+		}
+
+		revoked, err = revokeUserRefreshTokens(ctx, tx, userID, revokedAt)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return revoked, nil
+}
+
+func (repo *AuthRepository) ChangePassword(ctx context.Context, change domainauth.PasswordChange) (int, error) {
+	revoked := 0
+
+	err := repo.dbConn.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewUpdate().
+			Model((*userpostgres.DBUser)(nil)).
+			Set("password_hash = ?", change.PasswordHash).
+			Set("must_change_password = false").
+			Where("id = ?", change.UserID).
+			Where("password_hash = ?", change.PreviousHash).
+			Where("deleted_at IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		if rows == 0 {
+			return domain.ErrInvalidCurrentPassword
+		}
+
+		if !change.RevokeOtherSessions {
+			return nil
+		}
+
+		res, err = tx.NewUpdate().
+			Model((*DBRefreshToken)(nil)).
+			Set("revoked_at = ?", change.ChangedAt).
+			Where("user_id = ?", change.UserID).
+			Where("family_id <> ?", change.KeepFamilyID).
+			Where("revoked_at IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		rows, err = res.RowsAffected()
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		revoked = int(rows)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return revoked, nil
+}
+
 func mapAuthUniqueConstraint(constraintName string) error {
 	switch constraintName {
-	case "auth_refresh_tokens_token_hash_uidx":
+	case "auth_refresh_tokens_token_hash_uidx", "user_tokens_token_hash_uidx":
 		return domain.ErrConflict
 	default:
 		return pgroot.MapUserIdentityUniqueConstraint(constraintName)
@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"admin.com/admin-api/internal/domain"
+	domainauth "admin.com/admin-api/internal/domain/auth"
+	pgroot "admin.com/admin-api/internal/repository/postgres"
+	userpostgres "admin.com/admin-api/internal/repository/postgres/user"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+func (repo *AuthRepository) CreateUserToken(ctx context.Context, token *domainauth.UserToken) error {
+	model := fromDomainUserToken(token)
+
+	err := repo.dbConn.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewUpdate().
+			Model((*DBUserToken)(nil)).
+			Set("used_at = current_timestamp").
+			Where("user_id = ?", model.UserID).
+			Where("purpose = ?", model.Purpose).
+			Where("used_at IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		if _, err := tx.NewInsert().Model(model).Exec(ctx); err != nil {
+			return pgroot.MapPersistenceWriteError(err, mapAuthUniqueConstraint)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	syncDomainUserTokenFromModel(token, model)
+	return nil
+}
+
+func (repo *AuthRepository) VerifyEmail(ctx context.Context, tokenHash string, verifiedAt time.Time) (uuid.UUID, error) {
+	var userID uuid.UUID
+
+	err := repo.dbConn.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		token, err := consumeUserToken(ctx, tx, domainauth.UserTokenPurposeEmailVerification, tokenHash, verifiedAt)
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.NewUpdate().
+			Model((*userpostgres.DBUser)(nil)).
+			Set("email_verified_at = COALESCE(email_verified_at, ?)", verifiedAt).
+			Where("id = ?", token.UserID).
+			Where("deleted_at IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		if rows == 0 {
+			return domain.ErrNotFound
+		}
+
+		userID = token.UserID
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
+
+// SetPasswordWithToken also marks the email verified: the link reached it.
+func (repo *AuthRepository) SetPasswordWithToken(ctx context.Context, purpose domainauth.UserTokenPurpose, tokenHash string, passwordHash string, setAt time.Time) (uuid.UUID, int, error) {
+	var userID uuid.UUID
+	revoked := 0
+
+	err := repo.dbConn.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		token, err := consumeUserToken(ctx, tx, purpose, tokenHash, setAt)
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.NewUpdate().
+			Model((*userpostgres.DBUser)(nil)).
+			Set("password_hash = ?", passwordHash).
+			Set("must_change_password = false").
+			Set("tokens_valid_after = ?", setAt).
+			Set("email_verified_at = COALESCE(email_verified_at, ?)", setAt).
+			Where("id = ?", token.UserID).
+			Where("deleted_at IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		if rows == 0 {
+			return domain.ErrNotFound
+		}
+
+		revoked, err = revokeUserRefreshTokens(ctx, tx, token.UserID, setAt)
+		if err != nil {
+			return err
+		}
+
+		userID = token.UserID
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, 0, err
+	}
+
+	return userID, revoked, nil
+}
+
+func (repo *AuthRepository) GetActiveUserToken(ctx context.Context, purpose domainauth.UserTokenPurpose, tokenHash string, now time.Time) (*domainauth.UserToken, error) {
+	model := new(DBUserToken)
+	err := repo.dbConn.NewSelect().
+		Model(model).
+		Where("token_hash = ?", tokenHash).
+		Where("purpose = ?", string(purpose)).
+		Where("used_at IS NULL").
+		Where("expires_at > ?", now).
+		Scan(ctx)
+	if err != nil {
+		return nil, pgroot.MapSelectError(err)
+	}
+
+	return toDomainUserToken(model), nil
+}
+
+func (repo *AuthRepository) ConsumeUserToken(ctx context.Context, purpose domainauth.UserTokenPurpose, tokenHash string, usedAt time.Time) (*domainauth.UserToken, error) {
+	model, err := consumeUserToken(ctx, repo.dbConn, purpose, tokenHash, usedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainUserToken(model), nil
+}
+
+// consumeUserToken marks the token used in the same statement that checks it,
+// so two concurrent requests cannot both spend it.
+func consumeUserToken(ctx context.Context, db bun.IDB, purpose domainauth.UserTokenPurpose, tokenHash string, usedAt time.Time) (*DBUserToken, error) {
+	model := new(DBUserToken)
+	err := db.NewUpdate().
+		Model(model).
+		Set("used_at = ?", usedAt).
+		Where("token_hash = ?", tokenHash).
+		Where("purpose = ?", string(purpose)).
+		Where("used_at IS NULL").
+		Where("expires_at > ?", usedAt).
+		Returning("*").
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, pgroot.WrapInternal(err)
+	}
+
+	return model, nil
+}
@@ -18,6 +18,8 @@ const (
 
 type UniqueConstraintMapper func(constraintName string) error
 
+type ForeignKeyConstraintMapper func(constraintName string) error
+
 func MapPersistenceWriteError(err error, mapUniqueConstraint UniqueConstraintMapper) error {
 	var pgErr pgdriver.Error
 	if !errors.As(err, &pgErr) {
@@ -38,6 +40,24 @@ func MapPersistenceWriteError(err error, mapUniqueConstraint UniqueConstraintMap
 	}
 }
 
+func MapPersistenceDeleteError(err error, mapForeignKeyConstraint ForeignKeyConstraintMapper) error {
+	var pgErr pgdriver.Error
+	if !errors.As(err, &pgErr) {
+		return WrapInternal(err)
+	}
+
+	switch pgErr.Field('C') {
+	case pgErrCodeForeignKeyViolation:
+		if mapForeignKeyConstraint == nil {
+			return domain.ErrConflict
+		}
+
+		return mapForeignKeyConstraint(pgErr.Field('n'))
+	default:
+		return WrapInternal(err)
+	}
+}
+
 func WrapInternal(err error) error {
 	if err == nil {
 		return domain.ErrInternalServerError
@@ -56,11 +76,29 @@ func MapSelectError(err error) error {
 
 func MapUserIdentityUniqueConstraint(constraintName string) error {
 	switch constraintName {
-	case "users_username_key":
+	case "users_username_key", "users_username_active_uidx":
 		return errors.Join(domain.ErrConflict, domain.ErrUsernameExists)
-	case "users_email_key", "users_email_lower_uidx":
+	case "users_email_key", "users_email_lower_uidx", "users_email_lower_active_uidx":
 		return errors.Join(domain.ErrConflict, domain.ErrEmailExists)
 	default:
 		return domain.ErrConflict
 	}
 }
+
+func MapRoleUniqueConstraint(constraintName string) error {
+	switch constraintName {
+	case "roles_name_lower_uidx":
+		return errors.Join(domain.ErrConflict, domain.ErrRoleNameExists)
+	default:
+		return domain.ErrConflict
+	}
+}
+
+func MapRoleForeignKeyConstraint(constraintName string) error {
+	switch constraintName {
+	case "user_roles_role_id_fkey":
+		return errors.Join(domain.ErrConflict, domain.ErrRoleInUse)
+	default:
+		return domain.ErrConflict
+	}
+}
@@ -0,0 +1,331 @@
+package postgres
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// migrationLockID is the pg_advisory_lock key shared by every migration runner.
+const migrationLockID int64 = 7_305_164_993_226_121
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+var (
+	ErrMigrationChecksumMismatch = errors.New("migration checksum mismatch")
+	ErrUnknownMigration          = errors.New("applied migration not found in source")
+)
+
+type Migration struct {
+	Version  int64
+	Name     string
+	UpSQL    string
+	DownSQL  string
+	Checksum string
+}
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	Modified  bool
+}
+
+type DBSchemaMigration struct {
+	bun.BaseModel `bun:"table:schema_migrations,alias:sm"`
+
+	Version   int64     `bun:"version,pk"`
+	Name      string    `bun:"name,notnull"`
+	Checksum  string    `bun:"checksum,notnull"`
+	AppliedAt time.Time `bun:"applied_at,notnull"`
+}
+
+type Migrator struct {
+	dbConn     *bun.DB
+	migrations []Migration
+}
+
+func NewMigrator(dbConn *bun.DB, source fs.FS) (*Migrator, error) {
+	migrations, err := loadMigrations(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		dbConn:     dbConn,
+		migrations: migrations,
+	}, nil
+}
+
+// Up applies every pending migration in version order, each in its own transaction.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	applied := []Migration{}
+
+	err := m.withLock(ctx, func(conn bun.Conn) error {
+		records, err := m.verifiedRecords(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := records[migration.Version]; ok {
+				continue
+			}
+
+			if err := m.apply(ctx, conn, migration); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down rolls back the latest applied migrations, at most steps of them.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, fmt.Errorf("migration steps must be greater than zero")
+	}
+
+	rolledBack := []Migration{}
+
+	err := m.withLock(ctx, func(conn bun.Conn) error {
+		records, err := m.verifiedRecords(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(rolledBack) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := records[migration.Version]; !ok {
+				continue
+			}
+
+			if err := m.revert(ctx, conn, migration); err != nil {
+				return err
+			}
+			rolledBack = append(rolledBack, migration)
+		}
+
+		return nil
+	})
+
+	return rolledBack, err
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+
+	err := m.withLock(ctx, func(conn bun.Conn) error {
+		records, err := loadMigrationRecords(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if record, ok := records[migration.Version]; ok {
+				appliedAt := record.AppliedAt
+				status.Applied = true
+				status.AppliedAt = &appliedAt
+				status.Modified = record.Checksum != migration.Checksum
+			}
+			statuses = append(statuses, status)
+		}
+
+		return nil
+	})
+
+	return statuses, err
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(conn bun.Conn) error) (err error) {
+	// Session-level advisory locks belong to a connection, so the whole run
+	// has to stay on one connection instead of going through the pool.
+	conn, err := m.dbConn.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("migration connection failed: %w", err)
+	}
+	defer func() {
+		if closeErr := conn.Close(); err == nil && closeErr != nil {
+			err = closeErr
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock(?)", migrationLockID); err != nil {
+		return fmt.Errorf("migration lock failed: %w", err)
+	}
+	defer func() {
+		if _, unlockErr := conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock(?)", migrationLockID); err == nil && unlockErr != nil {
+			err = fmt.Errorf("migration unlock failed: %w", unlockErr)
+		}
+	}()
+
+	if _, err := conn.NewCreateTable().Model((*DBSchemaMigration)(nil)).IfNotExists().Exec(ctx); err != nil {
+		return fmt.Errorf("schema_migrations setup failed: %w", err)
+	}
+
+	return fn(conn)
+}
+
+func (m *Migrator) verifiedRecords(ctx context.Context, conn bun.Conn) (map[int64]DBSchemaMigration, error) {
+	records, err := loadMigrationRecords(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+
+	for version, record := range records {
+		migration, ok := known[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: version %d (%s)", ErrUnknownMigration, version, record.Name)
+		}
+
+		if migration.Checksum != record.Checksum {
+			return nil, fmt.Errorf("%w: version %d (%s)", ErrMigrationChecksumMismatch, version, migration.Name)
+		}
+	}
+
+	return records, nil
+}
+
+func (m *Migrator) apply(ctx context.Context, conn bun.Conn, migration Migration) error {
+	err := conn.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.ExecContext(ctx, migration.UpSQL); err != nil {
+			return err
+		}
+
+		record := &DBSchemaMigration{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Checksum:  migration.Checksum,
+			AppliedAt: time.Now().UTC(),
+		}
+		_, err := tx.NewInsert().Model(record).Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("migration %03d_%s up failed: %w", migration.Version, migration.Name, err)
+	}
+
+	return nil
+}
+
+func (m *Migrator) revert(ctx context.Context, conn bun.Conn, migration Migration) error {
+	err := conn.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.ExecContext(ctx, migration.DownSQL); err != nil {
+			return err
+		}
+
+		_, err := tx.NewDelete().Model((*DBSchemaMigration)(nil)).Where("version = ?", migration.Version).Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("migration %03d_%s down failed: %w", migration.Version, migration.Name, err)
+	}
+
+	return nil
+}
+
+func loadMigrationRecords(ctx context.Context, conn bun.Conn) (map[int64]DBSchemaMigration, error) {
+	var rows []DBSchemaMigration
+	if err := conn.NewSelect().Model(&rows).Order("version ASC").Scan(ctx); err != nil {
+		return nil, fmt.Errorf("schema_migrations read failed: %w", err)
+	}
+
+	records := make(map[int64]DBSchemaMigration, len(rows))
+	for _, row := range rows {
+		records[row.Version] = row
+	}
+
+	return records, nil
+}
+
+func loadMigrations(source fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, fmt.Errorf("migration source read failed: %w", err)
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s has invalid version", entry.Name())
+		}
+
+		content, err := fs.ReadFile(source, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("migration %s read failed: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d is used by %s and %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.UpSQL = string(content)
+		} else {
+			migration.DownSQL = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.UpSQL == "" || migration.DownSQL == "" {
+			return nil, fmt.Errorf("migration %03d_%s needs both up and down files", migration.Version, migration.Name)
+		}
+
+		migration.Checksum = migrationChecksum(migration.UpSQL, migration.DownSQL)
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// migrationChecksum covers both directions so an edited rollback is caught as
+// drift too. The separator keeps text moving between the files from hashing
+// the same.
+func migrationChecksum(upSQL, downSQL string) string {
+	hash := sha256.New()
+	hash.Write([]byte(upSQL))
+	hash.Write([]byte{0})
+	hash.Write([]byte(downSQL))
+	return hex.EncodeToString(hash.Sum(nil))
+}
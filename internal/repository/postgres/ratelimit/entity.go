@@ -0,0 +1,16 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type DBRateLimitBucket struct {
+	bun.BaseModel `bun:"table:rate_limit_buckets,alias:rlb"`
+
+	BucketKey string    `bun:"bucket_key,pk"`
+	Tokens    float64   `bun:"tokens,notnull"`
+	UpdatedAt time.Time `bun:"updated_at,notnull"`
+	FullAt    time.Time `bun:"full_at,notnull"`
+}
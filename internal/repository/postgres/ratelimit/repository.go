@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	domainratelimit "admin.com/admin-api/internal/domain/ratelimit"
+	pgroot "admin.com/admin-api/internal/repository/postgres"
+	"github.com/uptrace/bun"
+)
+
+// RateLimitStore shares the buckets between every API instance, at the cost of
+// a transaction per limited request.
+type RateLimitStore struct {
+	dbConn *bun.DB
+}
+
+func NewRateLimitStore(dbConn *bun.DB) *RateLimitStore {
+	return &RateLimitStore{
+		dbConn: dbConn,
+	}
+}
+
+func (repo *RateLimitStore) Take(ctx context.Context, key string, policy domainratelimit.Policy, now time.Time) (domainratelimit.Decision, error) {
+	now = now.UTC()
+	var decision domainratelimit.Decision
+
+	err := repo.dbConn.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// A bucket stored full at now is the same as a missing one, so the
+		// row is created first and then locked like any other.
+		result, err := tx.NewInsert().
+			Model(&DBRateLimitBucket{
+				BucketKey: key,
+				Tokens:    float64(policy.Limit),
+				UpdatedAt: now,
+				FullAt:    now,
+			}).
+			On("CONFLICT (bucket_key) DO NOTHING").
+			Exec(ctx)
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		inserted, err := result.RowsAffected()
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+		if inserted > 0 {
+			// New keys are rare compared to requests, so pruning here keeps
+			// the table small without a cleanup job.
+			_, err := tx.NewDelete().
+				Model((*DBRateLimitBucket)(nil)).
+				Where("full_at <= ?", now).
+				Where("bucket_key <> ?", key).
+				Exec(ctx)
+			if err != nil {
+				return pgroot.WrapInternal(err)
+			}
+		}
+
+		model := new(DBRateLimitBucket)
+		if err := tx.NewSelect().Model(model).Where("bucket_key = ?", key).For("UPDATE").Scan(ctx); err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		var bucket domainratelimit.Bucket
+		bucket, decision = policy.Take(domainratelimit.Bucket{Tokens: model.Tokens, UpdatedAt: model.UpdatedAt}, now)
+
+		_, err = tx.NewUpdate().
+			Model((*DBRateLimitBucket)(nil)).
+			Set("tokens = ?", bucket.Tokens).
+			Set("updated_at = ?", bucket.UpdatedAt).
+			Set("full_at = ?", bucket.FullAt(policy)).
+			Where("bucket_key = ?", key).
+			Exec(ctx)
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domainratelimit.Decision{}, err
+	}
+
+	return decision, nil
+}
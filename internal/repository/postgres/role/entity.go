@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type DBRole struct {
+	bun.BaseModel `bun:"table:roles,alias:r"`
+
+	ID          uuid.UUID `bun:"id,pk,type:uuid,default:gen_random_uuid()"`
+	Name        string    `bun:"name,unique,notnull"`
+	Description string    `bun:"description"`
+	CreatedAt   time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt   time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+}
+
+type DBUserRole struct {
+	bun.BaseModel `bun:"table:user_roles,alias:ur"`
+
+	UserID     uuid.UUID `bun:"user_id,pk,type:uuid,notnull"`
+	RoleID     uuid.UUID `bun:"role_id,pk,type:uuid,notnull"`
+	AssignedAt time.Time `bun:"assigned_at,nullzero,notnull,default:current_timestamp"`
+}
+
+type DBPermission struct {
+	bun.BaseModel `bun:"table:permissions,alias:p"`
+
+	ID          uuid.UUID `bun:"id,pk,type:uuid,default:gen_random_uuid()"`
+	Name        string    `bun:"name,unique,notnull"`
+	Description string    `bun:"description"`
+	CreatedAt   time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt   time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+}
+
+type DBRolePermission struct {
+	bun.BaseModel `bun:"table:role_permissions,alias:rp"`
+
+	RoleID       uuid.UUID `bun:"role_id,pk,type:uuid,notnull"`
+	PermissionID uuid.UUID `bun:"permission_id,pk,type:uuid,notnull"`
+	GrantedAt    time.Time `bun:"granted_at,nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,40 @@
+package postgres
+
+import roledomain "admin.com/admin-api/internal/domain/role"
+
+func ToDomainRole(model *DBRole) *roledomain.Role {
+	return &roledomain.Role{
+		ID:          model.ID,
+		Name:        model.Name,
+		Description: model.Description,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+}
+
+func ToDomainRoles(models []DBRole) []roledomain.Role {
+	roles := make([]roledomain.Role, len(models))
+	for i := range models {
+		roles[i] = *ToDomainRole(&models[i])
+	}
+
+	return roles
+}
+
+func FromDomainRole(role *roledomain.Role) *DBRole {
+	return &DBRole{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
+
+func SyncDomainRoleFromModel(dst *roledomain.Role, src *DBRole) {
+	dst.ID = src.ID
+	dst.Name = src.Name
+	dst.Description = src.Description
+	dst.CreatedAt = src.CreatedAt
+	dst.UpdatedAt = src.UpdatedAt
+}
@@ -0,0 +1,242 @@
+package postgres
+
+import (
+	"context"
+
+	"admin.com/admin-api/internal/domain"
+	roledomain "admin.com/admin-api/internal/domain/role"
+	pgroot "admin.com/admin-api/internal/repository/postgres"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type RoleRepository struct {
+	dbConn *bun.DB
+}
+
+func NewRoleRepository(dbConn *bun.DB) *RoleRepository {
+	return &RoleRepository{
+		dbConn: dbConn,
+	}
+}
+
+func (repo *RoleRepository) GetRoles(ctx context.Context) ([]roledomain.Role, error) {
+	var roles []DBRole
+
+	err := repo.dbConn.NewSelect().Model(&roles).Order("name ASC").Scan(ctx)
+	if err != nil {
+		return []roledomain.Role{}, pgroot.WrapInternal(err)
+	}
+
+	return ToDomainRoles(roles), nil
+}
+
+func (repo *RoleRepository) GetRoleByID(ctx context.Context, id uuid.UUID) (*roledomain.Role, error) {
+	model := new(DBRole)
+	if err := repo.dbConn.NewSelect().Model(model).Where("id = ?", id).Limit(1).Scan(ctx); err != nil {
+		return nil, pgroot.MapSelectError(err)
+	}
+
+	return ToDomainRole(model), nil
+}
+
+func (repo *RoleRepository) CreateRole(ctx context.Context, role *roledomain.Role) error {
+	model := FromDomainRole(role)
+
+	if _, err := repo.dbConn.NewInsert().Model(model).Exec(ctx); err != nil {
+		return pgroot.MapPersistenceWriteError(err, pgroot.MapRoleUniqueConstraint)
+	}
+
+	SyncDomainRoleFromModel(role, model)
+	return nil
+}
+
+func (repo *RoleRepository) UpdateRole(ctx context.Context, role *roledomain.Role) error {
+	model := FromDomainRole(role)
+
+	res, err := repo.dbConn.NewUpdate().
+		Model(model).
+		Column("name", "description").
+		Where("id = ?", role.ID).
+		Exec(ctx)
+
+	if err != nil {
+		return pgroot.MapPersistenceWriteError(err, pgroot.MapRoleUniqueConstraint)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return pgroot.WrapInternal(err)
+	}
+
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *RoleRepository) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	role := &DBRole{ID: id}
+
+	res, err := repo.dbConn.NewDelete().Model(role).WherePK().Exec(ctx)
+
+	if err != nil {
+		return pgroot.MapPersistenceDeleteError(err, pgroot.MapRoleForeignKeyConstraint)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return pgroot.WrapInternal(err)
+	}
+
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *RoleRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]roledomain.Role, error) {
+	return GetUserRoles(ctx, repo.dbConn, userID)
+}
+
+func (repo *RoleRepository) GetUserPermissions(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	return GetUserPermissions(ctx, repo.dbConn, userID)
+}
+
+func (repo *RoleRepository) AssignRole(ctx context.Context, userID uuid.UUID, roleID uuid.UUID) error {
+	model := &DBUserRole{
+		UserID: userID,
+		RoleID: roleID,
+	}
+
+	_, err := repo.dbConn.NewInsert().
+		Model(model).
+		On("CONFLICT (user_id, role_id) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return pgroot.MapPersistenceWriteError(err, nil)
+	}
+
+	return nil
+}
+
+func (repo *RoleRepository) RevokeRole(ctx context.Context, userID uuid.UUID, roleID uuid.UUID) error {
+	res, err := repo.dbConn.NewDelete().
+		Model((*DBUserRole)(nil)).
+		Where("user_id = ?", userID).
+		Where("role_id = ?", roleID).
+		Exec(ctx)
+	if err != nil {
+		return pgroot.WrapInternal(err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return pgroot.WrapInternal(err)
+	}
+
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *RoleRepository) GetRolePermissions(ctx context.Context, roleID uuid.UUID) ([]string, error) {
+	var permissions []string
+
+	err := repo.dbConn.NewSelect().
+		Model((*DBPermission)(nil)).
+		Column("p.name").
+		Join("JOIN role_permissions AS rp ON rp.permission_id = p.id").
+		Where("rp.role_id = ?", roleID).
+		OrderExpr("p.name ASC").
+		Scan(ctx, &permissions)
+	if err != nil {
+		return []string{}, pgroot.WrapInternal(err)
+	}
+
+	return permissions, nil
+}
+
+// GrantPermission is idempotent like AssignRole; an unknown permission name is
+// ErrNotFound.
+func (repo *RoleRepository) GrantPermission(ctx context.Context, roleID uuid.UUID, permission string) error {
+	permissionModel := new(DBPermission)
+	if err := repo.dbConn.NewSelect().Model(permissionModel).Where("name = ?", permission).Limit(1).Scan(ctx); err != nil {
+		return pgroot.MapSelectError(err)
+	}
+
+	model := &DBRolePermission{
+		RoleID:       roleID,
+		PermissionID: permissionModel.ID,
+	}
+
+	_, err := repo.dbConn.NewInsert().
+		Model(model).
+		On("CONFLICT (role_id, permission_id) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return pgroot.MapPersistenceWriteError(err, nil)
+	}
+
+	return nil
+}
+
+func (repo *RoleRepository) RevokePermission(ctx context.Context, roleID uuid.UUID, permission string) error {
+	res, err := repo.dbConn.NewDelete().
+		Model((*DBRolePermission)(nil)).
+		Where("role_id = ?", roleID).
+		Where("permission_id = (SELECT id FROM permissions WHERE name = ?)", permission).
+		Exec(ctx)
+	if err != nil {
+		return pgroot.WrapInternal(err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return pgroot.WrapInternal(err)
+	}
+
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func GetUserRoles(ctx context.Context, dbConn bun.IDB, userID uuid.UUID) ([]roledomain.Role, error) {
+	var roles []DBRole
+
+	err := dbConn.NewSelect().
+		Model(&roles).
+		Join("JOIN user_roles AS ur ON ur.role_id = r.id").
+		Where("ur.user_id = ?", userID).
+		Order("r.name ASC").
+		Scan(ctx)
+	if err != nil {
+		return []roledomain.Role{}, pgroot.WrapInternal(err)
+	}
+
+	return ToDomainRoles(roles), nil
+}
+
+func GetUserPermissions(ctx context.Context, dbConn bun.IDB, userID uuid.UUID) ([]string, error) {
+	var permissions []string
+
+	err := dbConn.NewSelect().
+		Model((*DBPermission)(nil)).
+		ColumnExpr("DISTINCT p.name").
+		Join("JOIN role_permissions AS rp ON rp.permission_id = p.id").
+		Join("JOIN user_roles AS ur ON ur.role_id = rp.role_id").
+		Where("ur.user_id = ?", userID).
+		OrderExpr("p.name ASC").
+		Scan(ctx, &permissions)
+	if err != nil {
+		return []string{}, pgroot.WrapInternal(err)
+	}
+
+	return permissions, nil
+}
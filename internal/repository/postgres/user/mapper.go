@@ -4,15 +4,20 @@ import userdomain "admin.com/admin-api/internal/domain/user"
 
 func ToDomainUser(model *DBUser) *userdomain.User {
 	return &userdomain.User{
-		ID:           model.ID,
-		Name:         model.Name,
-		LastName:     model.LastName,
-		Username:     model.Username,
-		PasswordHash: model.PasswordHash,
-		Email:        model.Email,
-		Avatar:       model.Avatar,
-		CreatedAt:    model.CreatedAt,
-		UpdatedAt:    model.UpdatedAt,
+		ID:                 model.ID,
+		Name:               model.Name,
+		LastName:           model.LastName,
+		Username:           model.Username,
+		PasswordHash:       model.PasswordHash,
+		MustChangePassword: model.MustChangePassword,
+		Email:              model.Email,
+		EmailVerifiedAt:    model.EmailVerifiedAt,
+		Avatar:             model.Avatar,
+		Status:             userdomain.Status(model.Status),
+		CreatedAt:          model.CreatedAt,
+		UpdatedAt:          model.UpdatedAt,
+		DeletedAt:          model.DeletedAt,
+		TokensValidAfter:   model.TokensValidAfter,
 	}
 }
 
@@ -25,17 +30,34 @@ func ToDomainUsers(models []DBUser) []userdomain.User {
 	return users
 }
 
+func ToDomainSearchResults(models []DBUserSearchResult) []userdomain.SearchResult {
+	results := make([]userdomain.SearchResult, len(models))
+	for i := range models {
+		results[i] = userdomain.SearchResult{
+			User:  *ToDomainUser(&models[i].DBUser),
+			Score: models[i].Score,
+		}
+	}
+
+	return results
+}
+
 func FromDomainUser(user *userdomain.User) *DBUser {
 	return &DBUser{
-		ID:           user.ID,
-		Name:         user.Name,
-		LastName:     user.LastName,
-		Username:     user.Username,
-		PasswordHash: user.PasswordHash,
-		Email:        user.Email,
-		Avatar:       user.Avatar,
-		CreatedAt:    user.CreatedAt,
-		UpdatedAt:    user.UpdatedAt,
+		ID:                 user.ID,
+		Name:               user.Name,
+		LastName:           user.LastName,
+		Username:           user.Username,
+		PasswordHash:       user.PasswordHash,
+		MustChangePassword: user.MustChangePassword,
+		Email:              user.Email,
+		EmailVerifiedAt:    user.EmailVerifiedAt,
+		Avatar:             user.Avatar,
+		Status:             string(user.Status),
+		CreatedAt:          user.CreatedAt,
+		UpdatedAt:          user.UpdatedAt,
+		DeletedAt:          user.DeletedAt,
+		TokensValidAfter:   user.TokensValidAfter,
 	}
 }
 
@@ -45,8 +67,13 @@ func SyncDomainUserFromModel(dst *userdomain.User, src *DBUser) {
 	dst.LastName = src.LastName
 	dst.Username = src.Username
 	dst.PasswordHash = src.PasswordHash
+	dst.MustChangePassword = src.MustChangePassword
 	dst.Email = src.Email
+	dst.EmailVerifiedAt = src.EmailVerifiedAt
 	dst.Avatar = src.Avatar
+	dst.Status = userdomain.Status(src.Status)
 	dst.CreatedAt = src.CreatedAt
 	dst.UpdatedAt = src.UpdatedAt
+	dst.DeletedAt = src.DeletedAt
+	dst.TokensValidAfter = src.TokensValidAfter
 }
@@ -2,7 +2,9 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"strings"
+	"time"
 
 	"admin.com/admin-api/internal/domain"
 	userdomain "admin.com/admin-api/internal/domain/user"
@@ -11,6 +13,9 @@ import (
 	"github.com/uptrace/bun"
 )
 
+// searchDocument must stay in sync with the users_search_trgm_idx expression.
+const searchDocument = "(u.name || ' ' || u.last_name || ' ' || u.username || ' ' || lower(u.email))"
+
 type UserRepository struct {
 	dbConn *bun.DB
 }
@@ -22,12 +27,7 @@ func NewUserRepository(dbConn *bun.DB) *UserRepository {
 }
 
 func (repo *UserRepository) GetUser(ctx context.Context, id uuid.UUID) (*userdomain.User, error) {
-	model := new(DBUser)
-	if err := repo.dbConn.NewSelect().Model(model).Where("id = ?", id).Limit(1).Scan(ctx); err != nil {
-		return nil, pgroot.MapSelectError(err)
-	}
-
-	return ToDomainUser(model), nil
+	return GetUserByID(ctx, repo.dbConn, id)
 }
 
 func (repo *UserRepository) CreateUser(ctx context.Context, user *userdomain.User) error {
@@ -41,10 +41,29 @@ func (repo *UserRepository) CreateUser(ctx context.Context, user *userdomain.Use
 	return nil
 }
 
-func (repo *UserRepository) GetUsers(ctx context.Context) ([]userdomain.User, error) {
+func (repo *UserRepository) GetUsers(ctx context.Context, query userdomain.ListQuery) ([]userdomain.User, error) {
 	var users []DBUser
 
-	err := repo.dbConn.NewSelect().Model(&users).Scan(ctx)
+	sortColumn := bun.Ident("u." + string(query.SortField))
+	comparison, direction := ">", "ASC"
+	if query.SortOrder == userdomain.SortDesc {
+		comparison, direction = "<", "DESC"
+	}
+
+	selectQuery := applyListFilter(repo.dbConn.NewSelect().Model(&users), query.Filter)
+	if query.After != nil {
+		afterValue, err := cursorValue(query.After)
+		if err != nil {
+			return []userdomain.User{}, err
+		}
+
+		selectQuery = selectQuery.Where("(?, u.id) "+comparison+" (?, ?)", sortColumn, afterValue, query.After.ID)
+	}
+
+	err := selectQuery.
+		OrderExpr("? "+direction+", u.id "+direction, sortColumn).
+		Limit(query.Limit).
+		Scan(ctx)
 	if err != nil {
 		return []userdomain.User{}, pgroot.WrapInternal(err)
 	}
@@ -52,13 +71,49 @@ func (repo *UserRepository) GetUsers(ctx context.Context) ([]userdomain.User, er
 	return ToDomainUsers(users), nil
 }
 
+func (repo *UserRepository) CountUsers(ctx context.Context, filter userdomain.ListFilter) (int, error) {
+	count, err := applyListFilter(repo.dbConn.NewSelect().Model((*DBUser)(nil)), filter).Count(ctx)
+	if err != nil {
+		return 0, pgroot.WrapInternal(err)
+	}
+
+	return count, nil
+}
+
+func (repo *UserRepository) SearchUsers(ctx context.Context, query userdomain.SearchQuery) ([]userdomain.SearchResult, error) {
+	var results []DBUserSearchResult
+
+	err := repo.dbConn.NewSelect().
+		Model(&results).
+		ColumnExpr("u.*").
+		ColumnExpr("word_similarity(?, "+searchDocument+") AS score", query.Term).
+		Where("u.deleted_at IS NULL").
+		Where("? <% "+searchDocument+" OR "+searchDocument+" ILIKE ? ESCAPE '\\'", query.Term, "%"+escapeLikePattern(query.Term)+"%").
+		OrderExpr("score DESC, u.username ASC").
+		Limit(query.Limit).
+		Scan(ctx)
+	if err != nil {
+		return []userdomain.SearchResult{}, pgroot.WrapInternal(err)
+	}
+
+	return ToDomainSearchResults(results), nil
+}
+
+// UpdateUser clears email_verified_at when the address changes; the caller
+// does not load the stored user, so the comparison happens in the statement.
 func (repo *UserRepository) UpdateUser(ctx context.Context, user *userdomain.User) error {
 	model := FromDomainUser(user)
 
 	res, err := repo.dbConn.NewUpdate().
 		Model(model).
-		Column("name", "last_name", "username", "email", "avatar").
+		Set("name = ?", model.Name).
+		Set("last_name = ?", model.LastName).
+		Set("username = ?", model.Username).
+		Set("email_verified_at = CASE WHEN u.email = ? THEN u.email_verified_at END", model.Email).
+		Set("email = ?", model.Email).
+		Set("avatar = ?", model.Avatar).
 		Where("id = ?", user.ID).
+		Where("deleted_at IS NULL").
 		Exec(ctx)
 
 	if err != nil {
@@ -77,11 +132,83 @@ func (repo *UserRepository) UpdateUser(ctx context.Context, user *userdomain.Use
 	return nil
 }
 
-func (repo *UserRepository) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	user := &DBUser{ID: id}
+func (repo *UserRepository) DeleteUser(ctx context.Context, id uuid.UUID, deletedAt time.Time) error {
+	return repo.dbConn.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewUpdate().
+			Model((*DBUser)(nil)).
+			Set("deleted_at = ?", deletedAt).
+			Where("id = ?", id).
+			Where("deleted_at IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
+
+		if err := requireAffectedRows(res); err != nil {
+			return err
+		}
+
+		return revokeUserRefreshTokens(ctx, tx, id, deletedAt)
+	})
+}
+
+func (repo *UserRepository) RestoreUser(ctx context.Context, id uuid.UUID) error {
+	res, err := repo.dbConn.NewUpdate().
+		Model((*DBUser)(nil)).
+		Set("deleted_at = NULL").
+		Where("id = ?", id).
+		Where("deleted_at IS NOT NULL").
+		Exec(ctx)
+	if err != nil {
+		// The username or email may have been taken while the user was deleted.
+		return pgroot.MapPersistenceWriteError(err, pgroot.MapUserIdentityUniqueConstraint)
+	}
+
+	return requireAffectedRows(res)
+}
+
+func (repo *UserRepository) ChangeUserStatus(ctx context.Context, change userdomain.StatusChange) error {
+	return repo.dbConn.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		query := tx.NewUpdate().
+			Model((*DBUser)(nil)).
+			Set("status = ?", string(change.To)).
+			Where("id = ?", change.UserID).
+			Where("status = ?", string(change.From)).
+			Where("deleted_at IS NULL")
+		if change.RevokeSessions {
+			query = query.Set("tokens_valid_after = ?", change.ChangedAt)
+		}
+
+		res, err := query.Exec(ctx)
+		if err != nil {
+			return pgroot.MapPersistenceWriteError(err, nil)
+		}
 
-	res, err := repo.dbConn.NewDelete().Model(user).WherePK().Exec(ctx)
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return pgroot.WrapInternal(err)
+		}
 
+		if rows == 0 {
+			return domain.ErrInvalidStatusTransition
+		}
+
+		if !change.RevokeSessions {
+			return nil
+		}
+
+		return revokeUserRefreshTokens(ctx, tx, change.UserID, change.ChangedAt)
+	})
+}
+
+// PurgeUser only removes users that were soft deleted first; a live user gets
+// domain.ErrUserNotDeleted.
+func (repo *UserRepository) PurgeUser(ctx context.Context, id uuid.UUID) error {
+	res, err := repo.dbConn.NewDelete().
+		Model((*DBUser)(nil)).
+		Where("id = ?", id).
+		Where("deleted_at IS NOT NULL").
+		Exec(ctx)
 	if err != nil {
 		return pgroot.WrapInternal(err)
 	}
@@ -91,16 +218,25 @@ func (repo *UserRepository) DeleteUser(ctx context.Context, id uuid.UUID) error
 		return pgroot.WrapInternal(err)
 	}
 
-	if rows == 0 {
-		return domain.ErrNotFound
+	if rows > 0 {
+		return nil
 	}
 
-	return nil
+	exists, err := repo.dbConn.NewSelect().Model((*DBUser)(nil)).Where("id = ?", id).Exists(ctx)
+	if err != nil {
+		return pgroot.WrapInternal(err)
+	}
+
+	if exists {
+		return domain.ErrUserNotDeleted
+	}
+
+	return domain.ErrNotFound
 }
 
 func GetUserByID(ctx context.Context, dbConn bun.IDB, id uuid.UUID) (*userdomain.User, error) {
 	model := new(DBUser)
-	if err := dbConn.NewSelect().Model(model).Where("id = ?", id).Limit(1).Scan(ctx); err != nil {
+	if err := dbConn.NewSelect().Model(model).Where("id = ?", id).Where("deleted_at IS NULL").Limit(1).Scan(ctx); err != nil {
 		return nil, pgroot.MapSelectError(err)
 	}
 
@@ -111,7 +247,7 @@ func GetUserByIdentity(ctx context.Context, dbConn bun.IDB, identity string) (*u
 	model := new(DBUser)
 
 	identity = strings.TrimSpace(identity)
-	query := dbConn.NewSelect().Model(model).Limit(1)
+	query := dbConn.NewSelect().Model(model).Where("deleted_at IS NULL").Limit(1)
 	if strings.Contains(identity, "@") {
 		query = query.Where("lower(email) = lower(?)", identity)
 	} else {
@@ -124,3 +260,54 @@ func GetUserByIdentity(ctx context.Context, dbConn bun.IDB, identity string) (*u
 
 	return ToDomainUser(model), nil
 }
+
+func applyListFilter(query *bun.SelectQuery, filter userdomain.ListFilter) *bun.SelectQuery {
+	query = query.Where("u.deleted_at IS NULL")
+	if filter.EmailPrefix != "" {
+		query = query.Where("lower(u.email) LIKE ? ESCAPE '\\'", escapeLikePattern(filter.EmailPrefix)+"%")
+	}
+	if filter.UsernamePrefix != "" {
+		query = query.Where("lower(u.username) LIKE ? ESCAPE '\\'", escapeLikePattern(filter.UsernamePrefix)+"%")
+	}
+
+	return query
+}
+
+func cursorValue(cursor *userdomain.ListCursor) (any, error) {
+	if cursor.SortField == userdomain.SortByUsername {
+		return cursor.Value, nil
+	}
+
+	return cursor.TimeValue()
+}
+
+func escapeLikePattern(value string) string {
+	return strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(value)
+}
+
+func requireAffectedRows(res sql.Result) error {
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return pgroot.WrapInternal(err)
+	}
+
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func revokeUserRefreshTokens(ctx context.Context, dbConn bun.IDB, userID uuid.UUID, revokedAt time.Time) error {
+	_, err := dbConn.NewUpdate().
+		Table("auth_refresh_tokens").
+		Set("revoked_at = ?", revokedAt).
+		Where("user_id = ?", userID).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return pgroot.WrapInternal(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+
+	domainauth "admin.com/admin-api/internal/domain/auth"
+	"admin.com/admin-api/pkg/logger"
+	"github.com/google/uuid"
+)
+
+// LogRecorder writes security events to the structured application log.
+type LogRecorder struct {
+	logger *slog.Logger
+}
+
+func NewLogRecorder(baseLogger *slog.Logger) *LogRecorder {
+	if baseLogger == nil {
+		baseLogger = slog.Default()
+	}
+
+	return &LogRecorder{logger: baseLogger}
+}
+
+func (r *LogRecorder) Record(ctx context.Context, event domainauth.SecurityEvent) {
+	attrs := []slog.Attr{
+		slog.String("event", string(event.Type)),
+		slog.Time("occurred_at", event.OccurredAt),
+	}
+	if event.UserID != uuid.Nil {
+		attrs = append(attrs, slog.String("user_id", event.UserID.String()))
+	}
+	if event.FamilyID != uuid.Nil {
+		attrs = append(attrs, slog.String("family_id", event.FamilyID.String()))
+	}
+	if event.TokenID != uuid.Nil {
+		attrs = append(attrs, slog.String("token_id", event.TokenID.String()))
+	}
+	for key, value := range event.Attributes {
+		attrs = append(attrs, slog.Any(key, value))
+	}
+
+	r.logger.LogAttrs(ctx, slog.LevelWarn, logger.MsgSecurityEvent, attrs...)
+}
@@ -0,0 +1,53 @@
+package denylist
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory keeps the denylist in process memory, so it only works for a single
+// API instance and is lost on restart.
+type Memory struct {
+	mu      sync.RWMutex
+	entries map[string]time.Time
+	now     func() time.Time
+}
+
+func NewMemory(now func() time.Time) *Memory {
+	if now == nil {
+		now = time.Now
+	}
+
+	return &Memory{
+		entries: map[string]time.Time{},
+		now:     now,
+	}
+}
+
+func (m *Memory) Deny(_ context.Context, tokenID string, expiresAt time.Time) error {
+	now := m.now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, entryExpiresAt := range m.entries {
+		if !entryExpiresAt.After(now) {
+			delete(m.entries, id)
+		}
+	}
+
+	if expiresAt.After(now) {
+		m.entries[tokenID] = expiresAt
+	}
+
+	return nil
+}
+
+func (m *Memory) IsDenied(_ context.Context, tokenID string) (bool, error) {
+	m.mu.RLock()
+	expiresAt, ok := m.entries[tokenID]
+	m.mu.RUnlock()
+
+	return ok && expiresAt.After(m.now()), nil
+}
@@ -0,0 +1,85 @@
+package loginattempt
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	domainauth "admin.com/admin-api/internal/domain/auth"
+)
+
+// Memory keeps the counters in process memory, so each API instance throttles
+// on its own and every counter is lost on restart.
+type Memory struct {
+	mu       sync.Mutex
+	attempts map[string]domainauth.LoginAttempt
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		attempts: map[string]domainauth.LoginAttempt{},
+	}
+}
+
+func (m *Memory) Get(_ context.Context, key string) (domainauth.LoginAttempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	attempt, ok := m.attempts[key]
+	if !ok {
+		return domainauth.LoginAttempt{Key: key}, nil
+	}
+
+	return attempt, nil
+}
+
+func (m *Memory) RecordFailure(_ context.Context, key string, at time.Time, policy domainauth.LoginThrottlePolicy) (domainauth.LoginAttempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	forgetBefore := at.Add(-policy.Retention())
+	for existingKey, existing := range m.attempts {
+		if existing.UpdatedAt.Before(forgetBefore) && !existing.IsLockedAt(at) {
+			delete(m.attempts, existingKey)
+		}
+	}
+
+	attempt, ok := m.attempts[key]
+	if !ok {
+		attempt = domainauth.LoginAttempt{Key: key, WindowStartedAt: at}
+	}
+	if !attempt.WindowStartedAt.After(at.Add(-policy.Window)) {
+		attempt.Failures = 0
+		attempt.WindowStartedAt = at
+	}
+
+	attempt.Failures++
+	attempt.UpdatedAt = at
+	m.attempts[key] = attempt
+
+	return attempt, nil
+}
+
+func (m *Memory) Lock(_ context.Context, key string, at time.Time, until time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	attempt := m.attempts[key]
+	attempt.Key = key
+	attempt.Failures = 0
+	attempt.WindowStartedAt = at
+	attempt.Lockouts++
+	attempt.LockedUntil = until
+	attempt.UpdatedAt = at
+	m.attempts[key] = attempt
+
+	return nil
+}
+
+func (m *Memory) Reset(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.attempts, key)
+	return nil
+}
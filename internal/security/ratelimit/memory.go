@@ -0,0 +1,59 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	domainratelimit "admin.com/admin-api/internal/domain/ratelimit"
+)
+
+const memoryPruneInterval = time.Minute
+
+type memoryBucket struct {
+	bucket domainratelimit.Bucket
+	fullAt time.Time
+}
+
+// Memory keeps the buckets in process memory: every API instance enforces the
+// limits on its own and the buckets are lost on restart.
+type Memory struct {
+	mu         sync.Mutex
+	buckets    map[string]memoryBucket
+	lastPruned time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		buckets: map[string]memoryBucket{},
+	}
+}
+
+func (m *Memory) Take(_ context.Context, key string, policy domainratelimit.Policy, now time.Time) (domainratelimit.Decision, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.prune(now)
+
+	bucket, decision := policy.Take(m.buckets[key].bucket, now)
+	m.buckets[key] = memoryBucket{
+		bucket: bucket,
+		fullAt: bucket.FullAt(policy),
+	}
+
+	return decision, nil
+}
+
+// prune drops buckets that refilled completely; a missing bucket is full.
+func (m *Memory) prune(now time.Time) {
+	if now.Sub(m.lastPruned) < memoryPruneInterval {
+		return
+	}
+
+	for key, stored := range m.buckets {
+		if !stored.fullAt.After(now) {
+			delete(m.buckets, key)
+		}
+	}
+	m.lastPruned = now
+}
@@ -10,6 +10,8 @@ const (
 	JWTIssuerRequiredMessage = InvalidConfiguration
 	JWTAudienceNeededMessage = InvalidConfiguration
 	JWTAccessTTLMessage      = InvalidConfiguration
+	JWTAlgorithmMessage      = InvalidConfiguration
+	JWTKeyInvalidMessage     = InvalidConfiguration
 )
 
 var (
@@ -19,4 +21,6 @@ var (
 	ErrJWTIssuerRequired = errors.New(JWTIssuerRequiredMessage)
 	ErrJWTAudienceNeeded = errors.New(JWTAudienceNeededMessage)
 	ErrJWTAccessTTL      = errors.New(JWTAccessTTLMessage)
+	ErrJWTAlgorithm      = errors.New(JWTAlgorithmMessage)
+	ErrJWTKeyInvalid     = errors.New(JWTKeyInvalidMessage)
 )
@@ -1,8 +1,6 @@
 package token
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -13,15 +11,18 @@ import (
 	"github.com/google/uuid"
 )
 
+// Config lists every key the API accepts; ActiveKeyID picks the one used for
+// signing (the first key when empty).
 type Config struct {
-	Secret    string
-	Issuer    string
-	Audience  string
-	AccessTTL time.Duration
+	Keys        []KeyConfig
+	ActiveKeyID string
+	Issuer      string
+	Audience    string
+	AccessTTL   time.Duration
 }
 
 type JWT struct {
-	secret    []byte
+	keys      *keyRing
 	issuer    string
 	audience  string
 	accessTTL time.Duration
@@ -31,21 +32,21 @@ type JWT struct {
 type jwtHeader struct {
 	Algorithm string `json:"alg"`
 	Type      string `json:"typ"`
+	KeyID     string `json:"kid,omitempty"`
 }
 
 type jwtClaims struct {
-	Subject  string `json:"sub"`
-	Audience string `json:"aud"`
-	Issuer   string `json:"iss"`
-	IssuedAt int64  `json:"iat"`
-	Expires  int64  `json:"exp"`
-	TokenID  string `json:"jti"`
+	Subject     string   `json:"sub"`
+	Audience    string   `json:"aud"`
+	Issuer      string   `json:"iss"`
+	IssuedAt    int64    `json:"iat"`
+	Expires     int64    `json:"exp"`
+	TokenID     string   `json:"jti"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"perms,omitempty"`
 }
 
 func NewJWT(cfg Config) (*JWT, error) {
-	if strings.TrimSpace(cfg.Secret) == "" {
-		return nil, ErrJWTSecretRequired
-	}
 	if strings.TrimSpace(cfg.Issuer) == "" {
 		return nil, ErrJWTIssuerRequired
 	}
@@ -56,8 +57,13 @@ func NewJWT(cfg Config) (*JWT, error) {
 		return nil, ErrJWTAccessTTL
 	}
 
+	keys, err := newKeyRing(cfg.Keys, cfg.ActiveKeyID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
 	return &JWT{
-		secret:    []byte(cfg.Secret),
+		keys:      keys,
 		issuer:    cfg.Issuer,
 		audience:  cfg.Audience,
 		accessTTL: cfg.AccessTTL,
@@ -65,22 +71,29 @@ func NewJWT(cfg Config) (*JWT, error) {
 	}, nil
 }
 
-func (j *JWT) GenerateAccessToken(userID uuid.UUID) (string, time.Time, error) {
+func (j *JWT) GenerateAccessToken(subject domainauth.AccessTokenSubject) (string, time.Time, error) {
+	if subject.UserID == uuid.Nil {
+		return "", time.Time{}, ErrInvalidToken
+	}
+
 	now := j.now().UTC()
 	expiresAt := now.Add(j.accessTTL)
 
 	claims := jwtClaims{
-		Subject:  userID.String(),
-		Audience: j.audience,
-		Issuer:   j.issuer,
-		IssuedAt: now.Unix(),
-		Expires:  expiresAt.Unix(),
-		TokenID:  uuid.NewString(),
+		Subject:     subject.UserID.String(),
+		Audience:    j.audience,
+		Issuer:      j.issuer,
+		IssuedAt:    now.Unix(),
+		Expires:     expiresAt.Unix(),
+		TokenID:     uuid.NewString(),
+		Roles:       subject.Roles,
+		Permissions: subject.Permissions,
 	}
 
 	header := jwtHeader{
-		Algorithm: "HS256",
+		Algorithm: j.keys.active.key.algorithm(),
 		Type:      "JWT",
+		KeyID:     j.keys.active.id,
 	}
 
 	token, err := j.sign(header, claims)
@@ -106,7 +119,14 @@ func (j *JWT) ParseAccessToken(token string) (*domainauth.AccessTokenClaims, err
 	if err := json.Unmarshal(headerJSON, &header); err != nil {
 		return nil, ErrInvalidToken
 	}
-	if header.Algorithm != "HS256" || header.Type != "JWT" {
+	if header.Type != "JWT" {
+		return nil, ErrInvalidToken
+	}
+
+	// The algorithm is pinned by the key the kid selects; trusting the header
+	// alone would allow alg=none or HS256-with-the-public-key confusion.
+	key, ok := j.keys.verificationKey(header.KeyID, header.Algorithm, j.now().UTC())
+	if !ok {
 		return nil, ErrInvalidToken
 	}
 
@@ -115,7 +135,7 @@ func (j *JWT) ParseAccessToken(token string) (*domainauth.AccessTokenClaims, err
 	if err != nil {
 		return nil, ErrInvalidToken
 	}
-	if !j.verifySignature(signedValue, signature) {
+	if !key.key.verify([]byte(signedValue), signature) {
 		return nil, ErrInvalidToken
 	}
 
@@ -148,12 +168,14 @@ func (j *JWT) ParseAccessToken(token string) (*domainauth.AccessTokenClaims, err
 	}
 
 	return &domainauth.AccessTokenClaims{
-		Subject:   claims.Subject,
-		Audience:  claims.Audience,
-		Issuer:    claims.Issuer,
-		IssuedAt:  time.Unix(claims.IssuedAt, 0).UTC(),
-		ExpiresAt: time.Unix(claims.Expires, 0).UTC(),
-		TokenID:   claims.TokenID,
+		Subject:     claims.Subject,
+		Audience:    claims.Audience,
+		Issuer:      claims.Issuer,
+		IssuedAt:    time.Unix(claims.IssuedAt, 0).UTC(),
+		ExpiresAt:   time.Unix(claims.Expires, 0).UTC(),
+		TokenID:     claims.TokenID,
+		Roles:       claims.Roles,
+		Permissions: claims.Permissions,
 	}, nil
 }
 
@@ -172,19 +194,17 @@ func (j *JWT) sign(header jwtHeader, claims jwtClaims) (string, error) {
 	claimsEncoded := base64.RawURLEncoding.EncodeToString(claimsJSON)
 	signedValue := headerEncoded + "." + claimsEncoded
 
-	signature := j.signValue(signedValue)
+	signature, err := j.keys.active.key.sign([]byte(signedValue))
+	if err != nil {
+		return "", fmt.Errorf("sign token: %w", err)
+	}
 	signatureEncoded := base64.RawURLEncoding.EncodeToString(signature)
 
 	return signedValue + "." + signatureEncoded, nil
 }
 
-func (j *JWT) signValue(value string) []byte {
-	mac := hmac.New(sha256.New, j.secret)
-	_, _ = mac.Write([]byte(value))
-	return mac.Sum(nil)
-}
-
-func (j *JWT) verifySignature(value string, provided []byte) bool {
-	expected := j.signValue(value)
-	return hmac.Equal(expected, provided)
+// PublicKeys lists the asymmetric keys that still verify tokens; HS256 keys
+// are shared secrets and are never published.
+func (j *JWT) PublicKeys() []domainauth.PublicKey {
+	return j.keys.publicKeys(j.now().UTC())
 }
@@ -0,0 +1,153 @@
+package token
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	domainauth "admin.com/admin-api/internal/domain/auth"
+	"admin.com/admin-api/pkg/logger"
+)
+
+// KeyConfig describes one key of the ring. HS256 keys use Secret, the others
+// PrivateKeyFile. A zero RetireAt means the key never stops verifying.
+type KeyConfig struct {
+	ID             string
+	Algorithm      string
+	Secret         string
+	PrivateKeyFile string
+	RetireAt       time.Time
+}
+
+type ringKey struct {
+	id       string
+	key      signingKey
+	retireAt time.Time
+}
+
+func (k ringKey) retiredAt(now time.Time) bool {
+	return !k.retireAt.IsZero() && !now.Before(k.retireAt)
+}
+
+// keyRing signs with a single active key and verifies with every key that is
+// not retired yet, so a rotation does not invalidate tokens already issued.
+type keyRing struct {
+	active ringKey
+	keys   map[string]ringKey
+	order  []string
+}
+
+func newKeyRing(configs []KeyConfig, activeID string, now time.Time) (*keyRing, error) {
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("%w: no signing keys configured", ErrJWTKeyInvalid)
+	}
+
+	ring := &keyRing{keys: make(map[string]ringKey, len(configs))}
+	for _, cfg := range configs {
+		// A retire_at that passed only means the rotation finished; the key
+		// is left out so a restart does not depend on editing the ring.
+		if !cfg.RetireAt.IsZero() && !now.Before(cfg.RetireAt) {
+			slog.Warn(logger.MsgJWTKeyRetired,
+				slog.String("kid", cfg.ID),
+				slog.Time("retire_at", cfg.RetireAt.UTC()),
+			)
+			continue
+		}
+
+		key, err := newSigningKey(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		id := strings.TrimSpace(cfg.ID)
+		if id == "" {
+			if publicKey, ok := key.publicKey(); ok {
+				id = thumbprint(publicKey)
+			}
+		}
+		if _, exists := ring.keys[id]; exists {
+			return nil, fmt.Errorf("%w: duplicate key id %q", ErrJWTKeyInvalid, id)
+		}
+
+		ring.keys[id] = ringKey{id: id, key: key, retireAt: cfg.RetireAt.UTC()}
+		ring.order = append(ring.order, id)
+	}
+
+	if len(ring.order) == 0 {
+		return nil, fmt.Errorf("%w: every configured key is retired", ErrJWTKeyInvalid)
+	}
+
+	activeID = strings.TrimSpace(activeID)
+	if activeID == "" {
+		activeID = ring.order[0]
+	}
+
+	active, ok := ring.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("%w: active key %q is not in the ring", ErrJWTKeyInvalid, activeID)
+	}
+	if !active.retireAt.IsZero() {
+		return nil, fmt.Errorf("%w: active key %q cannot have a retire time", ErrJWTKeyInvalid, activeID)
+	}
+	ring.active = active
+
+	return ring, nil
+}
+
+// verificationKey resolves the key named by a token header. Tokens without a
+// kid predate the ring and can only match the unnamed HS256 key, if any.
+func (r *keyRing) verificationKey(keyID string, algorithm string, now time.Time) (ringKey, bool) {
+	key, ok := r.keys[keyID]
+	if !ok || key.retiredAt(now) {
+		return ringKey{}, false
+	}
+
+	if key.key.algorithm() != algorithm {
+		return ringKey{}, false
+	}
+
+	return key, true
+}
+
+func (r *keyRing) publicKeys(now time.Time) []domainauth.PublicKey {
+	publicKeys := make([]domainauth.PublicKey, 0, len(r.order))
+	for _, id := range r.order {
+		key := r.keys[id]
+		if key.retiredAt(now) {
+			continue
+		}
+
+		publicKey, ok := key.key.publicKey()
+		if !ok {
+			continue
+		}
+
+		publicKey.KeyID = key.id
+		publicKeys = append(publicKeys, publicKey)
+	}
+
+	return publicKeys
+}
+
+func newSigningKey(cfg KeyConfig) (signingKey, error) {
+	algorithm := strings.TrimSpace(cfg.Algorithm)
+	if algorithm == "" {
+		algorithm = AlgorithmHS256
+	}
+
+	switch algorithm {
+	case AlgorithmHS256:
+		if strings.TrimSpace(cfg.Secret) == "" {
+			return nil, ErrJWTSecretRequired
+		}
+		return newHMACKey(cfg.Secret), nil
+	case AlgorithmRS256, AlgorithmES256, AlgorithmEdDSA:
+		if strings.TrimSpace(cfg.PrivateKeyFile) == "" {
+			return nil, fmt.Errorf("%w: %s needs a private key file", ErrJWTKeyInvalid, algorithm)
+		}
+		return loadPrivateKeyFile(algorithm, cfg.PrivateKeyFile)
+	default:
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrJWTAlgorithm, algorithm)
+	}
+}
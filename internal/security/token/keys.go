@@ -0,0 +1,256 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+
+	domainauth "admin.com/admin-api/internal/domain/auth"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+	AlgorithmEdDSA = "EdDSA"
+
+	minRSAKeyBits = 2048
+	es256KeySize  = 32
+)
+
+type signingKey interface {
+	algorithm() string
+	sign(value []byte) ([]byte, error)
+	verify(value []byte, signature []byte) bool
+	// publicKey returns false for symmetric keys, which must never be published.
+	publicKey() (domainauth.PublicKey, bool)
+}
+
+func newHMACKey(secret string) signingKey {
+	return hmacKey{secret: []byte(secret)}
+}
+
+type hmacKey struct {
+	secret []byte
+}
+
+func (k hmacKey) algorithm() string {
+	return AlgorithmHS256
+}
+
+func (k hmacKey) sign(value []byte) ([]byte, error) {
+	mac := hmac.New(sha256.New, k.secret)
+	_, _ = mac.Write(value)
+	return mac.Sum(nil), nil
+}
+
+func (k hmacKey) verify(value []byte, signature []byte) bool {
+	expected, _ := k.sign(value)
+	return hmac.Equal(expected, signature)
+}
+
+func (k hmacKey) publicKey() (domainauth.PublicKey, bool) {
+	return domainauth.PublicKey{}, false
+}
+
+type rsaKey struct {
+	private *rsa.PrivateKey
+}
+
+func (k rsaKey) algorithm() string {
+	return AlgorithmRS256
+}
+
+func (k rsaKey) sign(value []byte) ([]byte, error) {
+	digest := sha256.Sum256(value)
+	return rsa.SignPKCS1v15(rand.Reader, k.private, crypto.SHA256, digest[:])
+}
+
+func (k rsaKey) verify(value []byte, signature []byte) bool {
+	digest := sha256.Sum256(value)
+	return rsa.VerifyPKCS1v15(&k.private.PublicKey, crypto.SHA256, digest[:], signature) == nil
+}
+
+func (k rsaKey) publicKey() (domainauth.PublicKey, bool) {
+	return domainauth.PublicKey{
+		KeyType:   "RSA",
+		Algorithm: AlgorithmRS256,
+		N:         encodeSegment(k.private.PublicKey.N.Bytes()),
+		E:         encodeSegment(big.NewInt(int64(k.private.PublicKey.E)).Bytes()),
+	}, true
+}
+
+type ecdsaKey struct {
+	private *ecdsa.PrivateKey
+}
+
+func (k ecdsaKey) algorithm() string {
+	return AlgorithmES256
+}
+
+// ES256 signatures are the fixed-size r||s concatenation (RFC 7518 3.4), not ASN.1.
+func (k ecdsaKey) sign(value []byte) ([]byte, error) {
+	digest := sha256.Sum256(value)
+	r, s, err := ecdsa.Sign(rand.Reader, k.private, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	signature := make([]byte, 2*es256KeySize)
+	r.FillBytes(signature[:es256KeySize])
+	s.FillBytes(signature[es256KeySize:])
+	return signature, nil
+}
+
+func (k ecdsaKey) verify(value []byte, signature []byte) bool {
+	if len(signature) != 2*es256KeySize {
+		return false
+	}
+
+	digest := sha256.Sum256(value)
+	r := new(big.Int).SetBytes(signature[:es256KeySize])
+	s := new(big.Int).SetBytes(signature[es256KeySize:])
+	return ecdsa.Verify(&k.private.PublicKey, digest[:], r, s)
+}
+
+func (k ecdsaKey) publicKey() (domainauth.PublicKey, bool) {
+	x := make([]byte, es256KeySize)
+	y := make([]byte, es256KeySize)
+	k.private.PublicKey.X.FillBytes(x)
+	k.private.PublicKey.Y.FillBytes(y)
+
+	return domainauth.PublicKey{
+		KeyType:   "EC",
+		Algorithm: AlgorithmES256,
+		Curve:     "P-256",
+		X:         encodeSegment(x),
+		Y:         encodeSegment(y),
+	}, true
+}
+
+type ed25519Key struct {
+	private ed25519.PrivateKey
+}
+
+func (k ed25519Key) algorithm() string {
+	return AlgorithmEdDSA
+}
+
+func (k ed25519Key) sign(value []byte) ([]byte, error) {
+	return ed25519.Sign(k.private, value), nil
+}
+
+func (k ed25519Key) verify(value []byte, signature []byte) bool {
+	return ed25519.Verify(k.private.Public().(ed25519.PublicKey), value, signature)
+}
+
+func (k ed25519Key) publicKey() (domainauth.PublicKey, bool) {
+	return domainauth.PublicKey{
+		KeyType:   "OKP",
+		Algorithm: AlgorithmEdDSA,
+		Curve:     "Ed25519",
+		X:         encodeSegment(k.private.Public().(ed25519.PublicKey)),
+	}, true
+}
+
+func loadPrivateKeyFile(algorithm string, path string) (signingKey, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: read private key: %v", ErrJWTKeyInvalid, err)
+	}
+
+	return parsePrivateKeyPEM(algorithm, content)
+}
+
+func parsePrivateKeyPEM(algorithm string, content []byte) (signingKey, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM block found", ErrJWTKeyInvalid)
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrJWTKeyInvalid, err)
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		if algorithm != AlgorithmRS256 {
+			break
+		}
+		if key.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("%w: RSA keys need at least %d bits", ErrJWTKeyInvalid, minRSAKeyBits)
+		}
+		return rsaKey{private: key}, nil
+	case *ecdsa.PrivateKey:
+		if algorithm != AlgorithmES256 {
+			break
+		}
+		if key.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("%w: ES256 needs a P-256 key", ErrJWTKeyInvalid)
+		}
+		return ecdsaKey{private: key}, nil
+	case ed25519.PrivateKey:
+		if algorithm != AlgorithmEdDSA {
+			break
+		}
+		return ed25519Key{private: key}, nil
+	}
+
+	return nil, fmt.Errorf("%w: key type %T does not match algorithm %s", ErrJWTKeyInvalid, parsed, algorithm)
+}
+
+// thumbprint is the RFC 7638 JWK thumbprint, used as the default kid.
+func thumbprint(key domainauth.PublicKey) string {
+	var members any
+	switch key.KeyType {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{E: key.E, Kty: key.KeyType, N: key.N}
+	case "EC":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{Crv: key.Curve, Kty: key.KeyType, X: key.X, Y: key.Y}
+	default:
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{Crv: key.Curve, Kty: key.KeyType, X: key.X}
+	}
+
+	encoded, _ := json.Marshal(members)
+	sum := sha256.Sum256(encoded)
+	return encodeSegment(sum[:])
+}
+
+func encodeSegment(value []byte) string {
+	return base64.RawURLEncoding.EncodeToString(value)
+}
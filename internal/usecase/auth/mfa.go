@@ -0,0 +1,288 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"admin.com/admin-api/internal/domain"
+	domainauth "admin.com/admin-api/internal/domain/auth"
+	"admin.com/admin-api/pkg/totp"
+	"github.com/google/uuid"
+)
+
+// totpSkew accepts the codes of one step before and after the current one.
+const totpSkew = 1
+
+// SetupTOTP starts, or restarts, enrolment; the credential protects logins
+// only after VerifyTOTP proves the app produces the right codes. Wrong
+// passwords are throttled like wrong codes.
+func (s *authUseCase) SetupTOTP(ctx context.Context, input SetupTOTPInput) (*TOTPSetupOutput, error) {
+	if input.UserID == uuid.Nil {
+		return nil, domain.ErrUnauthorized
+	}
+	if input.CurrentPassword == "" {
+		return nil, domain.ErrBadRequest
+	}
+
+	now := s.now().UTC()
+	throttleKeys := s.loginThrottleKeys(domainauth.MFAThrottleKey(input.UserID), input.IPAddress)
+	if err := s.ensureLoginAllowed(ctx, throttleKeys, now); err != nil {
+		return nil, err
+	}
+
+	user, err := s.authRepo.GetUserByID(ctx, input.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	if err := s.comparePassword(user.PasswordHash, input.CurrentPassword); err != nil {
+		if err := s.recordLoginFailure(ctx, throttleKeys, now); !errors.Is(err, domain.ErrInvalidCredentials) {
+			return nil, err
+		}
+		return nil, domain.ErrInvalidCurrentPassword
+	}
+
+	enabled, err := s.hasConfirmedTOTP(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret(s.refreshTokenRand)
+	if err != nil {
+		return nil, domain.ErrInternalServerError
+	}
+
+	ciphertext, err := s.secretCipher.Seal(secret)
+	if err != nil {
+		return nil, domain.ErrInternalServerError
+	}
+
+	if err := s.authRepo.SaveTOTPCredential(ctx, &domainauth.TOTPCredential{
+		UserID:           user.ID,
+		SecretCiphertext: ciphertext,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &TOTPSetupOutput{
+		Secret: totp.EncodeSecret(secret),
+		URI:    totp.URI(s.mfa.Issuer, user.Email, secret),
+	}, nil
+}
+
+// VerifyTOTP confirms the pending credential and returns the recovery codes;
+// they are only stored as hashes, so this is the one time they are shown.
+func (s *authUseCase) VerifyTOTP(ctx context.Context, input VerifyTOTPInput) ([]string, error) {
+	if input.UserID == uuid.Nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	now := s.now().UTC()
+	throttleKeys := s.loginThrottleKeys(domainauth.MFAThrottleKey(input.UserID), input.IPAddress)
+	if err := s.ensureLoginAllowed(ctx, throttleKeys, now); err != nil {
+		return nil, err
+	}
+
+	credential, err := s.authRepo.GetTOTPCredential(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if credential.IsConfirmed() {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := s.secretCipher.Open(credential.SecretCiphertext)
+	if err != nil {
+		return nil, domain.ErrInternalServerError
+	}
+
+	step, ok := totp.Validate(secret, input.Code, now, totpSkew)
+	if !ok {
+		if err := s.recordLoginFailure(ctx, throttleKeys, now); !errors.Is(err, domain.ErrInvalidCredentials) {
+			return nil, err
+		}
+		return nil, domain.ErrInvalidMFACode
+	}
+
+	if err := s.loginAttempts.Reset(ctx, throttleKeys[0].key); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := domainauth.GenerateRecoveryCodes(s.refreshTokenRand)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, len(recoveryCodes))
+	for i, recoveryCode := range recoveryCodes {
+		hashes[i] = domainauth.HashRecoveryCode(recoveryCode)
+	}
+
+	if err := s.authRepo.ConfirmTOTPCredential(ctx, input.UserID, step, now, hashes); err != nil {
+		return nil, err
+	}
+
+	s.securityEvents.Record(ctx, domainauth.SecurityEvent{
+		Type:       domainauth.SecurityEventMFAEnabled,
+		UserID:     input.UserID,
+		OccurredAt: now,
+	})
+
+	return recoveryCodes, nil
+}
+
+// CompleteMFAChallenge counts wrong codes against the user and the client IP
+// like wrong passwords. The challenge is only spent by a correct code, so a
+// typo does not send the user back to the password step.
+func (s *authUseCase) CompleteMFAChallenge(ctx context.Context, input MFAChallengeInput) (*SessionOutput, error) {
+	challengeToken := strings.TrimSpace(input.ChallengeToken)
+	if challengeToken == "" {
+		return nil, domain.ErrInvalidUserToken
+	}
+
+	code := strings.TrimSpace(input.Code)
+	recoveryCode := strings.TrimSpace(input.RecoveryCode)
+	if (code == "") == (recoveryCode == "") {
+		return nil, domain.ErrBadRequest
+	}
+
+	now := s.now().UTC()
+	challengeHash := domainauth.HashUserToken(challengeToken)
+	challenge, err := s.authRepo.GetActiveUserToken(ctx, domainauth.UserTokenPurposeMFAChallenge, challengeHash, now)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidUserToken
+		}
+		return nil, err
+	}
+
+	throttleKeys := s.loginThrottleKeys(domainauth.MFAThrottleKey(challenge.UserID), input.IPAddress)
+	if err := s.ensureLoginAllowed(ctx, throttleKeys, now); err != nil {
+		return nil, err
+	}
+
+	user, err := s.authRepo.GetUserByID(ctx, challenge.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidUserToken
+		}
+		return nil, err
+	}
+
+	if err := user.EnsureCanAuthenticate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.verifySecondFactor(ctx, user.ID, code, recoveryCode, now); err != nil {
+		if !errors.Is(err, domain.ErrInvalidMFACode) {
+			return nil, err
+		}
+
+		if err := s.recordLoginFailure(ctx, throttleKeys, now); !errors.Is(err, domain.ErrInvalidCredentials) {
+			return nil, err
+		}
+		return nil, domain.ErrInvalidMFACode
+	}
+
+	if _, err := s.authRepo.ConsumeUserToken(ctx, domainauth.UserTokenPurposeMFAChallenge, challengeHash, now); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidUserToken
+		}
+		return nil, err
+	}
+
+	if err := s.loginAttempts.Reset(ctx, throttleKeys[0].key); err != nil {
+		return nil, err
+	}
+
+	return s.completeLogin(ctx, user, input.UserAgent, input.IPAddress)
+}
+
+func (s *authUseCase) verifySecondFactor(ctx context.Context, userID uuid.UUID, code string, recoveryCode string, now time.Time) error {
+	if recoveryCode != "" {
+		remaining, err := s.authRepo.UseRecoveryCode(ctx, userID, domainauth.HashRecoveryCode(recoveryCode), now)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrInvalidMFACode
+			}
+			return err
+		}
+
+		s.securityEvents.Record(ctx, domainauth.SecurityEvent{
+			Type:       domainauth.SecurityEventRecoveryCodeUsed,
+			UserID:     userID,
+			OccurredAt: now,
+			Attributes: map[string]any{
+				"remaining_codes": remaining,
+			},
+		})
+		return nil
+	}
+
+	credential, err := s.authRepo.GetTOTPCredential(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidMFACode
+		}
+		return err
+	}
+	if !credential.IsConfirmed() {
+		return domain.ErrInvalidMFACode
+	}
+
+	secret, err := s.secretCipher.Open(credential.SecretCiphertext)
+	if err != nil {
+		return domain.ErrInternalServerError
+	}
+
+	step, ok := totp.Validate(secret, code, now, totpSkew)
+	if !ok || step <= credential.LastUsedStep {
+		return domain.ErrInvalidMFACode
+	}
+
+	if err := s.authRepo.UseTOTPStep(ctx, userID, step); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidMFACode
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *authUseCase) hasConfirmedTOTP(ctx context.Context, userID uuid.UUID) (bool, error) {
+	credential, err := s.authRepo.GetTOTPCredential(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return credential.IsConfirmed(), nil
+}
+
+func (s *authUseCase) issueMFAChallenge(ctx context.Context, userID uuid.UUID) (*MFAChallengeOutput, error) {
+	token, tokenHash, err := s.generateRefreshTokenPair()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := s.now().UTC().Add(s.mfa.ChallengeTTL)
+	if err := s.authRepo.CreateUserToken(ctx, domainauth.NewUserToken(userID, domainauth.UserTokenPurposeMFAChallenge, tokenHash, expiresAt)); err != nil {
+		return nil, err
+	}
+
+	return &MFAChallengeOutput{
+		ChallengeToken: token,
+		ExpiresAt:      expiresAt,
+	}, nil
+}
@@ -16,19 +16,37 @@ type RegisterInput struct {
 }
 
 type LoginInput struct {
-	Identity string
+	Identity  string
+	Password  string
+	UserAgent string
+	IPAddress string
+}
+
+type ChangePasswordInput struct {
+	UserID              uuid.UUID
+	CurrentPassword     string
+	NewPassword         string
+	CurrentRefreshToken string
+	RevokeOtherSessions bool
+}
+
+type SetPasswordInput struct {
+	Token    string
 	Password string
 }
 
 type UserOutput struct {
-	ID        uuid.UUID
-	Name      string
-	LastName  string
-	Username  string
-	Email     string
-	Avatar    string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                 uuid.UUID
+	Name               string
+	LastName           string
+	Username           string
+	Email              string
+	EmailVerifiedAt    *time.Time
+	MustChangePassword bool
+	Avatar             string
+	Status             string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
 }
 
 type SessionOutput struct {
@@ -39,3 +57,55 @@ type SessionOutput struct {
 	RefreshExpiresAt time.Time
 	User             UserOutput
 }
+
+// LoginOutput carries either the session or, for users with MFA, the
+// challenge to complete first.
+type LoginOutput struct {
+	Session      *SessionOutput
+	MFAChallenge *MFAChallengeOutput
+}
+
+type MFAChallengeOutput struct {
+	ChallengeToken string
+	ExpiresAt      time.Time
+}
+
+// MFAChallengeInput takes either Code, from the authenticator app, or one of
+// the recovery codes.
+type MFAChallengeInput struct {
+	ChallengeToken string
+	Code           string
+	RecoveryCode   string
+	UserAgent      string
+	IPAddress      string
+}
+
+// SetupTOTPInput asks for the password again so a stolen access token alone
+// cannot enrol another authenticator.
+type SetupTOTPInput struct {
+	UserID          uuid.UUID
+	CurrentPassword string
+	IPAddress       string
+}
+
+type VerifyTOTPInput struct {
+	UserID    uuid.UUID
+	Code      string
+	IPAddress string
+}
+
+type TOTPSetupOutput struct {
+	Secret string
+	URI    string
+}
+
+type ActiveSessionOutput struct {
+	FamilyID    uuid.UUID
+	StartedAt   time.Time
+	LastUsedAt  time.Time
+	ExpiresAt   time.Time
+	UserAgent   string
+	IPAddress   string
+	DeviceLabel string
+	Current     bool
+}
@@ -3,24 +3,44 @@ package auth
 import (
 	"context"
 	"crypto/rand"
-	"encoding/base64"
 	"errors"
+	"fmt"
 	"io"
+	"net/url"
 	"strings"
 	"time"
 
 	"admin.com/admin-api/internal/domain"
 	domainauth "admin.com/admin-api/internal/domain/auth"
+	domainmail "admin.com/admin-api/internal/domain/mail"
 	userdomain "admin.com/admin-api/internal/domain/user"
+	"admin.com/admin-api/pkg/useragent"
 	"github.com/google/uuid"
 )
 
 type AuthUseCase interface {
 	Register(ctx context.Context, input RegisterInput) (*UserOutput, error)
-	Login(ctx context.Context, input LoginInput) (*SessionOutput, error)
+	Login(ctx context.Context, input LoginInput) (*LoginOutput, error)
+	CompleteMFAChallenge(ctx context.Context, input MFAChallengeInput) (*SessionOutput, error)
+	SetupTOTP(ctx context.Context, input SetupTOTPInput) (*TOTPSetupOutput, error)
+	VerifyTOTP(ctx context.Context, input VerifyTOTPInput) ([]string, error)
 	Refresh(ctx context.Context, refreshToken string) (*SessionOutput, error)
-	Logout(ctx context.Context, refreshToken string) error
+	Logout(ctx context.Context, refreshToken string, accessToken string) error
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, email string) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, input SetPasswordInput) error
+	AcceptInvitation(ctx context.Context, input SetPasswordInput) error
+	InviteUser(ctx context.Context, user *userdomain.User)
+	ResendInvitation(ctx context.Context, user *userdomain.User) error
+	ChangePassword(ctx context.Context, input ChangePasswordInput) error
 	Me(ctx context.Context, accessToken string) (*UserOutput, error)
+	Authenticate(ctx context.Context, accessToken string) (*domainauth.AccessTokenClaims, error)
+	ListSessions(ctx context.Context, userID uuid.UUID, currentRefreshToken string) ([]ActiveSessionOutput, error)
+	RevokeSession(ctx context.Context, userID uuid.UUID, familyID uuid.UUID) error
+	LogoutAll(ctx context.Context, userID uuid.UUID) error
+	ListUserSessions(ctx context.Context, userID uuid.UUID) ([]ActiveSessionOutput, error)
+	RevokeAllUserSessions(ctx context.Context, actorID uuid.UUID, userID uuid.UUID) error
 }
 
 type authUseCase struct {
@@ -31,6 +51,14 @@ type authUseCase struct {
 	comparePassword  func(hash string, password string) error
 	now              func() time.Time
 	refreshTokenRand io.Reader
+	securityEvents   domainauth.SecurityEventRecorder
+	denylist         domainauth.AccessTokenDenylist
+	loginAttempts    domainauth.LoginAttemptStore
+	loginThrottle    domainauth.LoginThrottlePolicy
+	mailer           domainmail.Mailer
+	accountEmails    AccountEmailConfig
+	secretCipher     domainauth.SecretCipher
+	mfa              MFAConfig
 }
 
 type Dependencies struct {
@@ -38,6 +66,31 @@ type Dependencies struct {
 	ComparePassword  func(hash string, password string) error
 	Now              func() time.Time
 	RefreshTokenRand io.Reader
+	SecurityEvents   domainauth.SecurityEventRecorder
+	Denylist         domainauth.AccessTokenDenylist
+	LoginAttempts    domainauth.LoginAttemptStore
+	LoginThrottle    domainauth.LoginThrottlePolicy
+	Mailer           domainmail.Mailer
+	AccountEmails    AccountEmailConfig
+	SecretCipher     domainauth.SecretCipher
+	MFA              MFAConfig
+}
+
+// MFAConfig names the account in authenticator apps after Issuer;
+// ChallengeTTL bounds the time between the password and the second factor.
+type MFAConfig struct {
+	Issuer       string
+	ChallengeTTL time.Duration
+}
+
+// AccountEmailConfig builds the links mailed to users from PublicURL, the
+// address of the frontend that posts the token back to the API.
+type AccountEmailConfig struct {
+	PublicURL            string
+	RequireVerifiedEmail bool
+	EmailVerificationTTL time.Duration
+	PasswordResetTTL     time.Duration
+	InvitationTTL        time.Duration
 }
 
 func NewAuthUseCase(
@@ -65,6 +118,37 @@ func NewAuthUseCase(
 	if dependencies.RefreshTokenRand == nil {
 		dependencies.RefreshTokenRand = rand.Reader
 	}
+	if dependencies.SecurityEvents == nil {
+		dependencies.SecurityEvents = noopSecurityEventRecorder{}
+	}
+	if dependencies.Denylist == nil {
+		dependencies.Denylist = noopAccessTokenDenylist{}
+	}
+	if dependencies.LoginAttempts == nil {
+		dependencies.LoginAttempts = noopLoginAttemptStore{}
+	}
+	if dependencies.Mailer == nil {
+		dependencies.Mailer = noopMailer{}
+	}
+	if dependencies.AccountEmails.EmailVerificationTTL <= 0 {
+		dependencies.AccountEmails.EmailVerificationTTL = 24 * time.Hour
+	}
+	if dependencies.AccountEmails.PasswordResetTTL <= 0 {
+		dependencies.AccountEmails.PasswordResetTTL = 30 * time.Minute
+	}
+	if dependencies.AccountEmails.InvitationTTL <= 0 {
+		dependencies.AccountEmails.InvitationTTL = 72 * time.Hour
+	}
+	dependencies.AccountEmails.PublicURL = strings.TrimRight(dependencies.AccountEmails.PublicURL, "/")
+	if dependencies.SecretCipher == nil {
+		dependencies.SecretCipher = unavailableSecretCipher{}
+	}
+	if strings.TrimSpace(dependencies.MFA.Issuer) == "" {
+		dependencies.MFA.Issuer = "admin-api"
+	}
+	if dependencies.MFA.ChallengeTTL <= 0 {
+		dependencies.MFA.ChallengeTTL = 5 * time.Minute
+	}
 
 	return &authUseCase{
 		authRepo:         authRepo,
@@ -74,6 +158,14 @@ func NewAuthUseCase(
 		comparePassword:  dependencies.ComparePassword,
 		now:              dependencies.Now,
 		refreshTokenRand: dependencies.RefreshTokenRand,
+		securityEvents:   dependencies.SecurityEvents,
+		denylist:         dependencies.Denylist,
+		loginAttempts:    dependencies.LoginAttempts,
+		loginThrottle:    dependencies.LoginThrottle.WithDefaults(),
+		mailer:           dependencies.Mailer,
+		accountEmails:    dependencies.AccountEmails,
+		secretCipher:     dependencies.SecretCipher,
+		mfa:              dependencies.MFA,
 	}
 }
 
@@ -111,11 +203,215 @@ func (s *authUseCase) Register(ctx context.Context, input RegisterInput) (*UserO
 		return nil, err
 	}
 
+	// The account exists even if the email fails; the user can ask for a new
+	// one through ResendVerification.
+	if err := s.sendEmailVerification(ctx, user); err != nil {
+		s.recordAccountEmailFailure(ctx, user.ID, domainauth.UserTokenPurposeEmailVerification, err)
+	}
+
 	userOut := toUserOutput(user)
 	return &userOut, nil
 }
 
-func (s *authUseCase) Login(ctx context.Context, input LoginInput) (*SessionOutput, error) {
+func (s *authUseCase) VerifyEmail(ctx context.Context, token string) error {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return domain.ErrInvalidUserToken
+	}
+
+	_, err := s.authRepo.VerifyEmail(ctx, domainauth.HashUserToken(token), s.now().UTC())
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidUserToken
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ForgotPassword answers the same way whether or not the address belongs to
+// an account that can sign in; only infrastructure failures are returned.
+func (s *authUseCase) ForgotPassword(ctx context.Context, email string) error {
+	user, err := s.findUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidEmail) {
+			return nil
+		}
+		return err
+	}
+	if user == nil {
+		return nil
+	}
+
+	if user.EnsureCanAuthenticate() != nil {
+		return nil
+	}
+
+	return s.sendPasswordReset(ctx, user)
+}
+
+// ResetPassword also ends every session of the user: whoever knew the old
+// password may still hold a refresh or access token.
+func (s *authUseCase) ResetPassword(ctx context.Context, input SetPasswordInput) error {
+	return s.setPasswordWithToken(ctx, domainauth.UserTokenPurposePasswordReset, input, domainauth.SecurityEventPasswordReset)
+}
+
+// AcceptInvitation sets the first password of a user created by an
+// administrator.
+func (s *authUseCase) AcceptInvitation(ctx context.Context, input SetPasswordInput) error {
+	return s.setPasswordWithToken(ctx, domainauth.UserTokenPurposeInvitation, input, domainauth.SecurityEventInvitationAccepted)
+}
+
+func (s *authUseCase) setPasswordWithToken(ctx context.Context, purpose domainauth.UserTokenPurpose, input SetPasswordInput, eventType domainauth.SecurityEventType) error {
+	token := strings.TrimSpace(input.Token)
+	if token == "" {
+		return domain.ErrInvalidUserToken
+	}
+
+	password := domain.TrimPassword(input.Password)
+	if password == "" {
+		return domain.ErrBadRequest
+	}
+	if err := domain.ValidatePassword(password); err != nil {
+		return err
+	}
+
+	passwordHash, err := s.hashPassword(password)
+	if err != nil {
+		return domain.ErrInternalServerError
+	}
+
+	now := s.now().UTC()
+	userID, revoked, err := s.authRepo.SetPasswordWithToken(ctx, purpose, domainauth.HashUserToken(token), passwordHash, now)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidUserToken
+		}
+		return err
+	}
+
+	s.securityEvents.Record(ctx, domainauth.SecurityEvent{
+		Type:       eventType,
+		UserID:     userID,
+		OccurredAt: now,
+		Attributes: map[string]any{
+			"revoked_tokens": revoked,
+		},
+	})
+
+	return nil
+}
+
+// ChangePassword keeps the caller's session, found through the refresh cookie,
+// when it revokes the others. Access tokens already issued to the revoked
+// sessions stay valid until they expire, as with LogoutAll.
+func (s *authUseCase) ChangePassword(ctx context.Context, input ChangePasswordInput) error {
+	if input.UserID == uuid.Nil {
+		return domain.ErrUnauthorized
+	}
+
+	data, err := domainauth.NormalizePasswordChange(domainauth.PasswordChangeData{
+		CurrentPassword: input.CurrentPassword,
+		NewPassword:     input.NewPassword,
+	})
+	if err != nil {
+		return err
+	}
+
+	user, err := s.authRepo.GetUserByID(ctx, input.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrUnauthorized
+		}
+		return err
+	}
+
+	if err := user.EnsureCanAuthenticate(); err != nil {
+		return err
+	}
+	if err := s.comparePassword(user.PasswordHash, data.CurrentPassword); err != nil {
+		return domain.ErrInvalidCurrentPassword
+	}
+	if data.NewPassword == data.CurrentPassword {
+		return domain.ErrPasswordReused
+	}
+
+	passwordHash, err := s.hashPassword(data.NewPassword)
+	if err != nil {
+		return domain.ErrInternalServerError
+	}
+
+	change := domainauth.PasswordChange{
+		UserID:              user.ID,
+		PreviousHash:        user.PasswordHash,
+		PasswordHash:        passwordHash,
+		ChangedAt:           s.now().UTC(),
+		RevokeOtherSessions: input.RevokeOtherSessions,
+	}
+	if change.RevokeOtherSessions {
+		change.KeepFamilyID = s.currentFamilyID(ctx, user.ID, input.CurrentRefreshToken)
+	}
+
+	revoked, err := s.authRepo.ChangePassword(ctx, change)
+	if err != nil {
+		return err
+	}
+
+	s.securityEvents.Record(ctx, domainauth.SecurityEvent{
+		Type:       domainauth.SecurityEventPasswordChanged,
+		UserID:     user.ID,
+		FamilyID:   change.KeepFamilyID,
+		OccurredAt: change.ChangedAt,
+		Attributes: map[string]any{
+			"revoked_tokens": revoked,
+		},
+	})
+
+	return nil
+}
+
+// ResendVerification answers the same way whether or not the address belongs
+// to an unverified account, so it cannot be used to discover accounts.
+func (s *authUseCase) ResendVerification(ctx context.Context, email string) error {
+	user, err := s.findUserByEmail(ctx, email)
+	if err != nil || user == nil {
+		return err
+	}
+
+	if user.IsEmailVerified() || user.EnsureCanAuthenticate() != nil {
+		return nil
+	}
+
+	return s.sendEmailVerification(ctx, user)
+}
+
+// findUserByEmail returns a nil user, not an error, when no account has the
+// address; identity lookups also match usernames, which must not count here.
+func (s *authUseCase) findUserByEmail(ctx context.Context, email string) (*userdomain.User, error) {
+	normalizedEmail, err := domain.NewEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.authRepo.GetUserByIdentity(ctx, normalizedEmail.String())
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if user.Email != normalizedEmail.String() {
+		return nil, nil
+	}
+
+	return user, nil
+}
+
+// Login stops after the password for users with a confirmed authenticator and
+// hands out a challenge that CompleteMFAChallenge exchanges for the session.
+func (s *authUseCase) Login(ctx context.Context, input LoginInput) (*LoginOutput, error) {
 	normalized, err := domainauth.NormalizeLogin(domainauth.LoginData{
 		Identity: input.Identity,
 		Password: input.Password,
@@ -125,18 +421,67 @@ func (s *authUseCase) Login(ctx context.Context, input LoginInput) (*SessionOutp
 	}
 
 	user, err := s.authRepo.GetUserByIdentity(ctx, normalized.Identity)
-	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
-			return nil, domain.ErrInvalidCredentials
-		}
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
 		return nil, err
 	}
 
+	accountKey := domainauth.IdentityThrottleKey(normalized.Identity)
+	if user != nil {
+		accountKey = domainauth.AccountThrottleKey(user.ID)
+	}
+
+	now := s.now().UTC()
+	throttleKeys := s.loginThrottleKeys(accountKey, input.IPAddress)
+	if err := s.ensureLoginAllowed(ctx, throttleKeys, now); err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, s.recordLoginFailure(ctx, throttleKeys, now)
+	}
+
 	if err := s.comparePassword(user.PasswordHash, normalized.Password); err != nil {
-		return nil, domain.ErrInvalidCredentials
+		return nil, s.recordLoginFailure(ctx, throttleKeys, now)
+	}
+
+	if err := user.EnsureCanAuthenticate(); err != nil {
+		return nil, err
+	}
+	if s.accountEmails.RequireVerifiedEmail && !user.IsEmailVerified() {
+		return nil, domain.ErrEmailNotVerified
+	}
+
+	// Only the account counter is reset: one valid login must not clear the
+	// failures an IP collected against other accounts.
+	if err := s.loginAttempts.Reset(ctx, throttleKeys[0].key); err != nil {
+		return nil, err
+	}
+
+	mfaEnabled, err := s.hasConfirmedTOTP(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfaEnabled {
+		challenge, err := s.issueMFAChallenge(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &LoginOutput{MFAChallenge: challenge}, nil
 	}
 
-	return s.createSessionForUser(ctx, user, uuid.Nil)
+	session, err := s.completeLogin(ctx, user, input.UserAgent, input.IPAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginOutput{Session: session}, nil
+}
+
+// completeLogin opens the session once every factor has been checked.
+func (s *authUseCase) completeLogin(ctx context.Context, user *userdomain.User, userAgent string, ipAddress string) (*SessionOutput, error) {
+	client := domainauth.NewClientMetadata(userAgent, ipAddress, useragent.Label(userAgent))
+	return s.createSessionForUser(ctx, user, uuid.Nil, client)
 }
 
 func (s *authUseCase) Refresh(ctx context.Context, refreshToken string) (*SessionOutput, error) {
@@ -155,6 +500,10 @@ func (s *authUseCase) Refresh(ctx context.Context, refreshToken string) (*Sessio
 		return nil, err
 	}
 
+	if storedToken.WasRotated() {
+		return nil, s.revokeReusedRefreshToken(ctx, storedToken, now)
+	}
+
 	if !storedToken.IsActiveAt(now) {
 		return nil, domain.ErrUnauthorized
 	}
@@ -167,24 +516,31 @@ func (s *authUseCase) Refresh(ctx context.Context, refreshToken string) (*Sessio
 		return nil, err
 	}
 
+	if err := user.EnsureCanAuthenticate(); err != nil {
+		return nil, err
+	}
+
 	newRefreshToken, newRefreshTokenHash, err := s.generateRefreshTokenPair()
 	if err != nil {
 		return nil, err
 	}
 
 	refreshExpiresAt := now.Add(s.refreshTokenTTL)
-	nextToken := domainauth.NewRefreshToken(storedToken.UserID, storedToken.FamilyID, newRefreshTokenHash, refreshExpiresAt)
+	nextToken := domainauth.NewRefreshToken(storedToken.UserID, storedToken.FamilyID, newRefreshTokenHash, refreshExpiresAt, storedToken.Client)
 
 	if err := s.authRepo.RotateRefreshToken(ctx, storedToken.ID, nextToken, now); err != nil {
-		if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrConflict) {
+		// ErrConflict means a concurrent request rotated or revoked the same
+		// token after it was read, usually two tabs refreshing at once. Only a
+		// token that was already rotated when presented counts as reuse.
+		if errors.Is(err, domain.ErrConflict) || errors.Is(err, domain.ErrNotFound) {
 			return nil, domain.ErrUnauthorized
 		}
 		return nil, err
 	}
 
-	accessToken, accessExpiresAt, err := s.tokenManager.GenerateAccessToken(user.ID)
+	accessToken, accessExpiresAt, err := s.generateAccessToken(ctx, user.ID)
 	if err != nil {
-		return nil, domain.ErrInternalServerError
+		return nil, err
 	}
 
 	return &SessionOutput{
@@ -197,7 +553,18 @@ func (s *authUseCase) Refresh(ctx context.Context, refreshToken string) (*Sessio
 	}, nil
 }
 
-func (s *authUseCase) Logout(ctx context.Context, refreshToken string) error {
+// Logout denylists the access token when one is sent, so it stops working
+// right away instead of at its expiry. Invalid or expired tokens are ignored.
+func (s *authUseCase) Logout(ctx context.Context, refreshToken string, accessToken string) error {
+	if accessToken = strings.TrimSpace(accessToken); accessToken != "" {
+		claims, err := s.tokenManager.ParseAccessToken(accessToken)
+		if err == nil && claims.TokenID != "" {
+			if err := s.denylist.Deny(ctx, claims.TokenID, claims.ExpiresAt); err != nil {
+				return err
+			}
+		}
+	}
+
 	refreshToken = strings.TrimSpace(refreshToken)
 	if refreshToken == "" {
 		return nil
@@ -213,41 +580,189 @@ func (s *authUseCase) Logout(ctx context.Context, refreshToken string) error {
 }
 
 func (s *authUseCase) Me(ctx context.Context, accessToken string) (*UserOutput, error) {
+	user, _, err := s.authenticateAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	userOut := toUserOutput(user)
+	return &userOut, nil
+}
+
+// Authenticate is the check every protected route runs: a valid signature is
+// not enough, the token must also postdate the user's last forced revocation.
+func (s *authUseCase) Authenticate(ctx context.Context, accessToken string) (*domainauth.AccessTokenClaims, error) {
+	_, claims, err := s.authenticateAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// ListSessions flags the family of currentRefreshToken, when the caller sent
+// one, so clients can tell which entry is the device they are on.
+func (s *authUseCase) ListSessions(ctx context.Context, userID uuid.UUID, currentRefreshToken string) ([]ActiveSessionOutput, error) {
+	if userID == uuid.Nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	sessions, err := s.authRepo.GetActiveSessions(ctx, userID, s.now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	return toActiveSessionOutputs(sessions, s.currentFamilyID(ctx, userID, currentRefreshToken)), nil
+}
+
+func (s *authUseCase) RevokeSession(ctx context.Context, userID uuid.UUID, familyID uuid.UUID) error {
+	if userID == uuid.Nil {
+		return domain.ErrUnauthorized
+	}
+	if familyID == uuid.Nil {
+		return domain.ErrBadRequest
+	}
+
+	return s.authRepo.RevokeUserSession(ctx, userID, familyID, s.now().UTC())
+}
+
+func (s *authUseCase) LogoutAll(ctx context.Context, userID uuid.UUID) error {
+	if userID == uuid.Nil {
+		return domain.ErrUnauthorized
+	}
+
+	_, err := s.authRepo.RevokeUserSessions(ctx, userID, s.now().UTC())
+	return err
+}
+
+func (s *authUseCase) ListUserSessions(ctx context.Context, userID uuid.UUID) ([]ActiveSessionOutput, error) {
+	if userID == uuid.Nil {
+		return nil, domain.ErrBadRequest
+	}
+
+	if _, err := s.authRepo.GetUserByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.authRepo.GetActiveSessions(ctx, userID, s.now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	return toActiveSessionOutputs(sessions, uuid.Nil), nil
+}
+
+func (s *authUseCase) RevokeAllUserSessions(ctx context.Context, actorID uuid.UUID, userID uuid.UUID) error {
+	if userID == uuid.Nil {
+		return domain.ErrBadRequest
+	}
+
+	now := s.now().UTC()
+	revoked, err := s.authRepo.RevokeUserAccess(ctx, userID, now)
+	if err != nil {
+		return err
+	}
+
+	s.securityEvents.Record(ctx, domainauth.SecurityEvent{
+		Type:       domainauth.SecurityEventUserSessionsRevoked,
+		UserID:     userID,
+		OccurredAt: now,
+		Attributes: map[string]any{
+			"actor_id":       actorID.String(),
+			"revoked_tokens": revoked,
+		},
+	})
+
+	return nil
+}
+
+func (s *authUseCase) authenticateAccessToken(ctx context.Context, accessToken string) (*userdomain.User, *domainauth.AccessTokenClaims, error) {
 	accessToken = strings.TrimSpace(accessToken)
 	if accessToken == "" {
-		return nil, domain.ErrUnauthorized
+		return nil, nil, domain.ErrUnauthorized
 	}
 
 	claims, err := s.tokenManager.ParseAccessToken(accessToken)
 	if err != nil {
-		return nil, domain.ErrUnauthorized
+		return nil, nil, domain.ErrUnauthorized
 	}
 
 	userID, err := uuid.Parse(claims.Subject)
 	if err != nil {
-		return nil, domain.ErrUnauthorized
+		return nil, nil, domain.ErrUnauthorized
+	}
+
+	if claims.TokenID != "" {
+		denied, err := s.denylist.IsDenied(ctx, claims.TokenID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if denied {
+			return nil, nil, domain.ErrUnauthorized
+		}
 	}
 
 	user, err := s.authRepo.GetUserByID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
-			return nil, domain.ErrUnauthorized
+			return nil, nil, domain.ErrUnauthorized
 		}
-		return nil, err
+		return nil, nil, err
 	}
 
-	userOut := toUserOutput(user)
-	return &userOut, nil
+	if !user.AcceptsTokenIssuedAt(claims.IssuedAt) {
+		return nil, nil, domain.ErrUnauthorized
+	}
+
+	return user, claims, nil
+}
+
+func (s *authUseCase) currentFamilyID(ctx context.Context, userID uuid.UUID, refreshToken string) uuid.UUID {
+	refreshToken = strings.TrimSpace(refreshToken)
+	if refreshToken == "" {
+		return uuid.Nil
+	}
+
+	token, err := s.authRepo.GetRefreshTokenByHash(ctx, domainauth.HashRefreshToken(refreshToken))
+	if err != nil || token.UserID != userID {
+		return uuid.Nil
+	}
+
+	return token.FamilyID
+}
+
+// revokeReusedRefreshToken kills the whole family: once a rotated token shows up
+// again there is no way to tell the legitimate client from the copy.
+func (s *authUseCase) revokeReusedRefreshToken(ctx context.Context, token *domainauth.RefreshToken, now time.Time) error {
+	revoked, err := s.authRepo.RevokeRefreshTokenFamily(ctx, token.FamilyID, now)
+	if err != nil {
+		return err
+	}
+
+	s.securityEvents.Record(ctx, domainauth.SecurityEvent{
+		Type:       domainauth.SecurityEventRefreshTokenReused,
+		UserID:     token.UserID,
+		FamilyID:   token.FamilyID,
+		TokenID:    token.ID,
+		OccurredAt: now,
+		Attributes: map[string]any{
+			"revoked_tokens": revoked,
+			"device":         token.Client.DeviceLabel,
+			"ip_address":     token.Client.IPAddress,
+		},
+	})
+
+	return domain.ErrRefreshTokenReused
 }
 
-func (s *authUseCase) createSessionForUser(ctx context.Context, user *userdomain.User, familyID uuid.UUID) (*SessionOutput, error) {
+func (s *authUseCase) createSessionForUser(ctx context.Context, user *userdomain.User, familyID uuid.UUID, client domainauth.ClientMetadata) (*SessionOutput, error) {
 	if user == nil || user.ID == uuid.Nil {
 		return nil, domain.ErrInternalServerError
 	}
 
-	accessToken, accessExpiresAt, err := s.tokenManager.GenerateAccessToken(user.ID)
+	accessToken, accessExpiresAt, err := s.generateAccessToken(ctx, user.ID)
 	if err != nil {
-		return nil, domain.ErrInternalServerError
+		return nil, err
 	}
 
 	rawRefreshToken, refreshTokenHash, err := s.generateRefreshTokenPair()
@@ -261,7 +776,7 @@ func (s *authUseCase) createSessionForUser(ctx context.Context, user *userdomain
 	}
 
 	refreshExpiresAt := now.Add(s.refreshTokenTTL)
-	refreshToken := domainauth.NewRefreshToken(user.ID, familyID, refreshTokenHash, refreshExpiresAt)
+	refreshToken := domainauth.NewRefreshToken(user.ID, familyID, refreshTokenHash, refreshExpiresAt, client)
 	if err := s.authRepo.CreateRefreshToken(ctx, refreshToken); err != nil {
 		return nil, err
 	}
@@ -276,25 +791,275 @@ func (s *authUseCase) createSessionForUser(ctx context.Context, user *userdomain
 	}, nil
 }
 
+func (s *authUseCase) generateAccessToken(ctx context.Context, userID uuid.UUID) (string, time.Time, error) {
+	roles, err := s.authRepo.GetUserRoleNames(ctx, userID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	permissions, err := s.authRepo.GetUserPermissions(ctx, userID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	accessToken, expiresAt, err := s.tokenManager.GenerateAccessToken(domainauth.AccessTokenSubject{
+		UserID:      userID,
+		Roles:       roles,
+		Permissions: permissions,
+	})
+	if err != nil {
+		return "", time.Time{}, domain.ErrInternalServerError
+	}
+
+	return accessToken, expiresAt, nil
+}
+
+func (s *authUseCase) sendEmailVerification(ctx context.Context, user *userdomain.User) error {
+	link, expiresAt, err := s.issueUserToken(ctx, user.ID, domainauth.UserTokenPurposeEmailVerification, s.accountEmails.EmailVerificationTTL, "/verify-email")
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, domainmail.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nConfirm your email address by opening this link:\n\n%s\n\nThe link expires at %s.\n",
+			user.Name, link, expiresAt.Format(time.RFC1123),
+		),
+	})
+}
+
+func (s *authUseCase) sendPasswordReset(ctx context.Context, user *userdomain.User) error {
+	link, expiresAt, err := s.issueUserToken(ctx, user.ID, domainauth.UserTokenPurposePasswordReset, s.accountEmails.PasswordResetTTL, "/reset-password")
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, domainmail.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nChoose a new password by opening this link:\n\n%s\n\nThe link expires at %s. If you did not ask for it, ignore this email; your password has not changed.\n",
+			user.Name, link, expiresAt.Format(time.RFC1123),
+		),
+	})
+}
+
+// InviteUser mails the single-use link the user opens to choose their first
+// password; until then the account has no usable password.
+func (s *authUseCase) InviteUser(ctx context.Context, user *userdomain.User) {
+	if err := s.sendInvitation(ctx, user); err != nil {
+		s.recordAccountEmailFailure(ctx, user.ID, domainauth.UserTokenPurposeInvitation, err)
+	}
+}
+
+func (s *authUseCase) ResendInvitation(ctx context.Context, user *userdomain.User) error {
+	return s.sendInvitation(ctx, user)
+}
+
+func (s *authUseCase) sendInvitation(ctx context.Context, user *userdomain.User) error {
+	link, expiresAt, err := s.issueUserToken(ctx, user.ID, domainauth.UserTokenPurposeInvitation, s.accountEmails.InvitationTTL, "/accept-invitation")
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, domainmail.Message{
+		To:      user.Email,
+		Subject: "You have been invited",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nAn account with the username %s was created for you. Choose your password by opening this link:\n\n%s\n\nThe link expires at %s.\n",
+			user.Name, user.Username, link, expiresAt.Format(time.RFC1123),
+		),
+	})
+}
+
+// recordAccountEmailFailure keeps a failed email visible when the change it
+// follows is already stored and the request must not fail.
+func (s *authUseCase) recordAccountEmailFailure(ctx context.Context, userID uuid.UUID, purpose domainauth.UserTokenPurpose, err error) {
+	s.securityEvents.Record(ctx, domainauth.SecurityEvent{
+		Type:       domainauth.SecurityEventAccountEmailFailed,
+		UserID:     userID,
+		OccurredAt: s.now().UTC(),
+		Attributes: map[string]any{
+			"purpose": string(purpose),
+			"error":   err.Error(),
+		},
+	})
+}
+
+// issueUserToken stores a new token for purpose and returns the frontend link
+// that carries it.
+func (s *authUseCase) issueUserToken(ctx context.Context, userID uuid.UUID, purpose domainauth.UserTokenPurpose, ttl time.Duration, path string) (string, time.Time, error) {
+	token, tokenHash, err := s.generateRefreshTokenPair()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	expiresAt := s.now().UTC().Add(ttl)
+	if err := s.authRepo.CreateUserToken(ctx, domainauth.NewUserToken(userID, purpose, tokenHash, expiresAt)); err != nil {
+		return "", time.Time{}, err
+	}
+
+	return s.accountEmails.PublicURL + path + "?token=" + url.QueryEscape(token), expiresAt, nil
+}
+
+// generateRefreshTokenPair also produces the tokens mailed to users; both are
+// opaque random values stored only as hashes.
 func (s *authUseCase) generateRefreshTokenPair() (string, string, error) {
-	raw := make([]byte, 48)
-	if _, err := io.ReadFull(s.refreshTokenRand, raw); err != nil {
-		return "", "", domain.ErrInternalServerError
+	return domainauth.GenerateUserToken(s.refreshTokenRand)
+}
+
+func toActiveSessionOutputs(sessions []domainauth.Session, currentFamilyID uuid.UUID) []ActiveSessionOutput {
+	output := make([]ActiveSessionOutput, 0, len(sessions))
+	for _, session := range sessions {
+		output = append(output, ActiveSessionOutput{
+			FamilyID:    session.FamilyID,
+			StartedAt:   session.StartedAt,
+			LastUsedAt:  session.LastUsedAt,
+			ExpiresAt:   session.ExpiresAt,
+			UserAgent:   session.Client.UserAgent,
+			IPAddress:   session.Client.IPAddress,
+			DeviceLabel: session.Client.DeviceLabel,
+			Current:     currentFamilyID != uuid.Nil && session.FamilyID == currentFamilyID,
+		})
 	}
 
-	refreshToken := base64.RawURLEncoding.EncodeToString(raw)
-	return refreshToken, domainauth.HashRefreshToken(refreshToken), nil
+	return output
 }
 
 func toUserOutput(user *userdomain.User) UserOutput {
 	return UserOutput{
-		ID:        user.ID,
-		Name:      user.Name,
-		LastName:  user.LastName,
-		Username:  user.Username,
-		Email:     user.Email,
-		Avatar:    user.Avatar,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:                 user.ID,
+		Name:               user.Name,
+		LastName:           user.LastName,
+		Username:           user.Username,
+		Email:              user.Email,
+		EmailVerifiedAt:    user.EmailVerifiedAt,
+		MustChangePassword: user.MustChangePassword,
+		Avatar:             user.Avatar,
+		Status:             string(user.Status),
+		CreatedAt:          user.CreatedAt,
+		UpdatedAt:          user.UpdatedAt,
+	}
+}
+
+type loginThrottleKey struct {
+	key         string
+	maxFailures int
+}
+
+// loginThrottleKeys puts the account key first; it is the one a success
+// resets.
+func (s *authUseCase) loginThrottleKeys(accountKey string, ipAddress string) []loginThrottleKey {
+	keys := []loginThrottleKey{{
+		key:         accountKey,
+		maxFailures: s.loginThrottle.MaxAccountFailures,
+	}}
+
+	if ipAddress = strings.TrimSpace(ipAddress); ipAddress != "" {
+		keys = append(keys, loginThrottleKey{
+			key:         domainauth.IPThrottleKey(ipAddress),
+			maxFailures: s.loginThrottle.MaxIPFailures,
+		})
+	}
+
+	return keys
+}
+
+func (s *authUseCase) ensureLoginAllowed(ctx context.Context, keys []loginThrottleKey, now time.Time) error {
+	var lockedUntil time.Time
+	for _, key := range keys {
+		attempt, err := s.loginAttempts.Get(ctx, key.key)
+		if err != nil {
+			return err
+		}
+
+		if attempt.IsLockedAt(now) && attempt.LockedUntil.After(lockedUntil) {
+			lockedUntil = attempt.LockedUntil
+		}
+	}
+
+	if lockedUntil.IsZero() {
+		return nil
+	}
+
+	return &domainauth.LoginLockedError{RetryAfter: lockedUntil.Sub(now)}
+}
+
+// recordLoginFailure counts a failed login for every key and locks the keys
+// that reached their limit. Unknown identities are counted like wrong
+// passwords so the response does not reveal which accounts exist.
+func (s *authUseCase) recordLoginFailure(ctx context.Context, keys []loginThrottleKey, now time.Time) error {
+	var lockout time.Duration
+	for _, key := range keys {
+		attempt, err := s.loginAttempts.RecordFailure(ctx, key.key, now, s.loginThrottle)
+		if err != nil {
+			return err
+		}
+
+		if attempt.Failures < key.maxFailures {
+			continue
+		}
+
+		keyLockout := s.loginThrottle.LockoutFor(attempt.Lockouts)
+		if err := s.loginAttempts.Lock(ctx, key.key, now, now.Add(keyLockout)); err != nil {
+			return err
+		}
+		lockout = max(lockout, keyLockout)
+	}
+
+	if lockout > 0 {
+		return &domainauth.LoginLockedError{RetryAfter: lockout}
 	}
+
+	return domain.ErrInvalidCredentials
+}
+
+type noopSecurityEventRecorder struct{}
+
+func (noopSecurityEventRecorder) Record(context.Context, domainauth.SecurityEvent) {}
+
+type noopAccessTokenDenylist struct{}
+
+func (noopAccessTokenDenylist) Deny(context.Context, string, time.Time) error {
+	return nil
+}
+
+func (noopAccessTokenDenylist) IsDenied(context.Context, string) (bool, error) {
+	return false, nil
+}
+
+type noopLoginAttemptStore struct{}
+
+func (noopLoginAttemptStore) Get(_ context.Context, key string) (domainauth.LoginAttempt, error) {
+	return domainauth.LoginAttempt{Key: key}, nil
+}
+
+func (noopLoginAttemptStore) RecordFailure(_ context.Context, key string, at time.Time, _ domainauth.LoginThrottlePolicy) (domainauth.LoginAttempt, error) {
+	return domainauth.LoginAttempt{Key: key, WindowStartedAt: at, UpdatedAt: at}, nil
+}
+
+func (noopLoginAttemptStore) Lock(context.Context, string, time.Time, time.Time) error {
+	return nil
+}
+
+func (noopLoginAttemptStore) Reset(context.Context, string) error {
+	return nil
+}
+
+type noopMailer struct{}
+
+func (noopMailer) Send(context.Context, domainmail.Message) error {
+	return nil
+}
+
+type unavailableSecretCipher struct{}
+
+func (unavailableSecretCipher) Seal([]byte) (string, error) {
+	return "", domain.ErrInternalServerError
+}
+
+func (unavailableSecretCipher) Open(string) ([]byte, error) {
+	return nil, domain.ErrInternalServerError
 }
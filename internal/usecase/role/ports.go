@@ -0,0 +1,26 @@
+package role
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateRoleInput struct {
+	Name        string
+	Description string
+}
+
+type UpdateRoleInput struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+}
+
+type RoleOutput struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,221 @@
+package role
+
+import (
+	"context"
+
+	"admin.com/admin-api/internal/domain"
+	roledomain "admin.com/admin-api/internal/domain/role"
+	userdomain "admin.com/admin-api/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+type RoleUseCase interface {
+	GetRoles(ctx context.Context) ([]RoleOutput, error)
+	GetRole(ctx context.Context, id uuid.UUID) (*RoleOutput, error)
+	CreateRole(ctx context.Context, input CreateRoleInput) (*RoleOutput, error)
+	UpdateRole(ctx context.Context, input UpdateRoleInput) (*RoleOutput, error)
+	DeleteRole(ctx context.Context, id uuid.UUID) error
+	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]RoleOutput, error)
+	AssignRole(ctx context.Context, userID uuid.UUID, roleID uuid.UUID) error
+	RevokeRole(ctx context.Context, userID uuid.UUID, roleID uuid.UUID) error
+	GetRolePermissions(ctx context.Context, roleID uuid.UUID) ([]string, error)
+	GrantPermission(ctx context.Context, roleID uuid.UUID, permission string) error
+	RevokePermission(ctx context.Context, roleID uuid.UUID, permission string) error
+}
+
+type roleUseCase struct {
+	roleRepo roledomain.RoleRepository
+	userRepo userdomain.UserRepository
+}
+
+func NewRoleUseCase(roleRepo roledomain.RoleRepository, userRepo userdomain.UserRepository) RoleUseCase {
+	return &roleUseCase{
+		roleRepo: roleRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *roleUseCase) GetRoles(ctx context.Context) ([]RoleOutput, error) {
+	roles, err := s.roleRepo.GetRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRoleOutputs(roles), nil
+}
+
+func (s *roleUseCase) GetRole(ctx context.Context, id uuid.UUID) (*RoleOutput, error) {
+	if id == uuid.Nil {
+		return nil, domain.ErrBadRequest
+	}
+
+	role, err := s.roleRepo.GetRoleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	roleOut := toRoleOutput(role)
+	return &roleOut, nil
+}
+
+func (s *roleUseCase) CreateRole(ctx context.Context, input CreateRoleInput) (*RoleOutput, error) {
+	role, err := roledomain.NewRole(roledomain.RoleDetails{
+		Name:        input.Name,
+		Description: input.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.roleRepo.CreateRole(ctx, role); err != nil {
+		return nil, err
+	}
+
+	roleOut := toRoleOutput(role)
+	return &roleOut, nil
+}
+
+func (s *roleUseCase) UpdateRole(ctx context.Context, input UpdateRoleInput) (*RoleOutput, error) {
+	if input.ID == uuid.Nil {
+		return nil, domain.ErrBadRequest
+	}
+
+	role := &roledomain.Role{ID: input.ID}
+	if err := role.SetDetails(roledomain.RoleDetails{
+		Name:        input.Name,
+		Description: input.Description,
+	}); err != nil {
+		return nil, err
+	}
+
+	currentRole, err := s.roleRepo.GetRoleByID(ctx, role.ID)
+	if err != nil {
+		return nil, err
+	}
+	if currentRole.IsBuiltIn() && currentRole.Name != role.Name {
+		return nil, domain.ErrBuiltInRole
+	}
+
+	if err := s.roleRepo.UpdateRole(ctx, role); err != nil {
+		return nil, err
+	}
+
+	updatedRole, err := s.roleRepo.GetRoleByID(ctx, role.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	roleOut := toRoleOutput(updatedRole)
+	return &roleOut, nil
+}
+
+func (s *roleUseCase) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return domain.ErrBadRequest
+	}
+
+	role, err := s.roleRepo.GetRoleByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if role.IsBuiltIn() {
+		return domain.ErrBuiltInRole
+	}
+
+	return s.roleRepo.DeleteRole(ctx, id)
+}
+
+func (s *roleUseCase) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]RoleOutput, error) {
+	if userID == uuid.Nil {
+		return nil, domain.ErrBadRequest
+	}
+
+	roles, err := s.roleRepo.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRoleOutputs(roles), nil
+}
+
+func (s *roleUseCase) AssignRole(ctx context.Context, userID uuid.UUID, roleID uuid.UUID) error {
+	if userID == uuid.Nil || roleID == uuid.Nil {
+		return domain.ErrBadRequest
+	}
+
+	if _, err := s.userRepo.GetUser(ctx, userID); err != nil {
+		return err
+	}
+	if _, err := s.roleRepo.GetRoleByID(ctx, roleID); err != nil {
+		return err
+	}
+
+	return s.roleRepo.AssignRole(ctx, userID, roleID)
+}
+
+func (s *roleUseCase) RevokeRole(ctx context.Context, userID uuid.UUID, roleID uuid.UUID) error {
+	if userID == uuid.Nil || roleID == uuid.Nil {
+		return domain.ErrBadRequest
+	}
+
+	return s.roleRepo.RevokeRole(ctx, userID, roleID)
+}
+
+func (s *roleUseCase) GetRolePermissions(ctx context.Context, roleID uuid.UUID) ([]string, error) {
+	if roleID == uuid.Nil {
+		return nil, domain.ErrBadRequest
+	}
+
+	if _, err := s.roleRepo.GetRoleByID(ctx, roleID); err != nil {
+		return nil, err
+	}
+
+	return s.roleRepo.GetRolePermissions(ctx, roleID)
+}
+
+func (s *roleUseCase) GrantPermission(ctx context.Context, roleID uuid.UUID, permission string) error {
+	if roleID == uuid.Nil || permission == "" {
+		return domain.ErrBadRequest
+	}
+
+	if _, err := s.roleRepo.GetRoleByID(ctx, roleID); err != nil {
+		return err
+	}
+
+	return s.roleRepo.GrantPermission(ctx, roleID, permission)
+}
+
+func (s *roleUseCase) RevokePermission(ctx context.Context, roleID uuid.UUID, permission string) error {
+	if roleID == uuid.Nil || permission == "" {
+		return domain.ErrBadRequest
+	}
+
+	role, err := s.roleRepo.GetRoleByID(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	if role.KeepsAllPermissions() {
+		return domain.ErrBuiltInRole
+	}
+
+	return s.roleRepo.RevokePermission(ctx, roleID, permission)
+}
+
+func toRoleOutput(role *roledomain.Role) RoleOutput {
+	return RoleOutput{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
+
+func toRoleOutputs(roles []roledomain.Role) []RoleOutput {
+	roleOutputs := make([]RoleOutput, len(roles))
+	for i := range roles {
+		roleOutputs[i] = toRoleOutput(&roles[i])
+	}
+
+	return roleOutputs
+}
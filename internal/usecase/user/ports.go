@@ -23,13 +23,42 @@ type UpdateUserInput struct {
 	Avatar   string
 }
 
+type ListUsersInput struct {
+	Limit          int
+	Cursor         string
+	Sort           string
+	Order          string
+	EmailPrefix    string
+	UsernamePrefix string
+}
+
+type UserPageOutput struct {
+	Users      []UserOutput
+	NextCursor string
+	Total      int
+	Limit      int
+}
+
+type SearchUsersInput struct {
+	Query string
+	Limit int
+}
+
+type UserSearchOutput struct {
+	User  UserOutput
+	Score float64
+}
+
 type UserOutput struct {
-	ID        uuid.UUID
-	Name      string
-	LastName  string
-	Username  string
-	Email     string
-	Avatar    string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                 uuid.UUID
+	Name               string
+	LastName           string
+	Username           string
+	Email              string
+	EmailVerifiedAt    *time.Time
+	MustChangePassword bool
+	Avatar             string
+	Status             string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
 }
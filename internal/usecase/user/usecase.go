@@ -2,8 +2,10 @@ package user
 
 import (
 	"context"
+	"time"
 
 	"admin.com/admin-api/internal/domain"
+	domainauth "admin.com/admin-api/internal/domain/auth"
 	userdomain "admin.com/admin-api/internal/domain/user"
 	"github.com/google/uuid"
 )
@@ -11,26 +13,41 @@ import (
 type UserUseCase interface {
 	GetUser(ctx context.Context, id uuid.UUID) (*UserOutput, error)
 	CreateUser(ctx context.Context, input CreateUserInput) (*UserOutput, error)
-	GetUsers(ctx context.Context) ([]UserOutput, error)
+	GetUsers(ctx context.Context, input ListUsersInput) (*UserPageOutput, error)
+	SearchUsers(ctx context.Context, input SearchUsersInput) ([]UserSearchOutput, error)
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	RestoreUser(ctx context.Context, id uuid.UUID) (*UserOutput, error)
+	PurgeUser(ctx context.Context, id uuid.UUID) error
+	SuspendUser(ctx context.Context, id uuid.UUID) (*UserOutput, error)
+	ActivateUser(ctx context.Context, id uuid.UUID) (*UserOutput, error)
+	LockUser(ctx context.Context, id uuid.UUID) (*UserOutput, error)
+	ResendInvitation(ctx context.Context, id uuid.UUID) error
 	UpdateUser(ctx context.Context, input UpdateUserInput) (*UserOutput, error)
 }
 
 type userUseCase struct {
-	userRepo     userdomain.UserRepository
-	hashPassword func(password string) (string, error)
+	userRepo userdomain.UserRepository
+	inviter  domainauth.Inviter
+	now      func() time.Time
 }
 
-func NewUserUseCase(userRepo userdomain.UserRepository, hashPassword func(password string) (string, error)) UserUseCase {
-	if hashPassword == nil {
-		hashPassword = func(string) (string, error) {
-			return "", domain.ErrInternalServerError
-		}
+type Dependencies struct {
+	Inviter domainauth.Inviter
+	Now     func() time.Time
+}
+
+func NewUserUseCase(userRepo userdomain.UserRepository, dependencies Dependencies) UserUseCase {
+	if dependencies.Inviter == nil {
+		dependencies.Inviter = noopInviter{}
+	}
+	if dependencies.Now == nil {
+		dependencies.Now = time.Now
 	}
 
 	return &userUseCase{
-		userRepo:     userRepo,
-		hashPassword: hashPassword,
+		userRepo: userRepo,
+		inviter:  dependencies.Inviter,
+		now:      dependencies.Now,
 	}
 }
 
@@ -60,30 +77,83 @@ func (s *userUseCase) CreateUser(ctx context.Context, input CreateUserInput) (*U
 		return nil, err
 	}
 
-	if err := userdomain.SetTemporaryPassword(user, s.hashPassword); err != nil {
-		return nil, err
-	}
+	user.RequirePasswordSetup()
 
 	if err := s.userRepo.CreateUser(ctx, user); err != nil {
 		return nil, err
 	}
 
+	s.inviter.InviteUser(ctx, user)
+
 	userOut := toUserOutput(user)
 	return &userOut, nil
 }
 
-func (s *userUseCase) GetUsers(ctx context.Context) ([]UserOutput, error) {
-	users, err := s.userRepo.GetUsers(ctx)
+func (s *userUseCase) GetUsers(ctx context.Context, input ListUsersInput) (*UserPageOutput, error) {
+	query, err := userdomain.NewListQuery(userdomain.ListParams{
+		Limit:          input.Limit,
+		Cursor:         input.Cursor,
+		Sort:           input.Sort,
+		Order:          input.Order,
+		EmailPrefix:    input.EmailPrefix,
+		UsernamePrefix: input.UsernamePrefix,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pageQuery := query
+	pageQuery.Limit = query.Limit + 1
+
+	users, err := s.userRepo.GetUsers(ctx, pageQuery)
 	if err != nil {
 		return nil, err
 	}
 
+	total, err := s.userRepo.CountUsers(ctx, query.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var nextCursor string
+	if len(users) > query.Limit {
+		users = users[:query.Limit]
+		nextCursor = query.CursorAfter(&users[len(users)-1])
+	}
+
 	userOutputs := make([]UserOutput, len(users))
 	for i := range users {
 		userOutputs[i] = toUserOutput(&users[i])
 	}
 
-	return userOutputs, nil
+	return &UserPageOutput{
+		Users:      userOutputs,
+		NextCursor: nextCursor,
+		Total:      total,
+		Limit:      query.Limit,
+	}, nil
+}
+
+func (s *userUseCase) SearchUsers(ctx context.Context, input SearchUsersInput) ([]UserSearchOutput, error) {
+	query, err := userdomain.NewSearchQuery(input.Query, input.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.userRepo.SearchUsers(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	searchOutputs := make([]UserSearchOutput, len(results))
+	for i := range results {
+		searchOutputs[i] = UserSearchOutput{
+			User:  toUserOutput(&results[i].User),
+			Score: results[i].Score,
+		}
+	}
+
+	return searchOutputs, nil
 }
 
 func (s *userUseCase) DeleteUser(ctx context.Context, id uuid.UUID) error {
@@ -91,7 +161,63 @@ func (s *userUseCase) DeleteUser(ctx context.Context, id uuid.UUID) error {
 		return domain.ErrBadRequest
 	}
 
-	return s.userRepo.DeleteUser(ctx, id)
+	return s.userRepo.DeleteUser(ctx, id, s.now().UTC())
+}
+
+func (s *userUseCase) RestoreUser(ctx context.Context, id uuid.UUID) (*UserOutput, error) {
+	if id == uuid.Nil {
+		return nil, domain.ErrBadRequest
+	}
+
+	if err := s.userRepo.RestoreUser(ctx, id); err != nil {
+		return nil, err
+	}
+
+	restoredUser, err := s.userRepo.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	userOut := toUserOutput(restoredUser)
+	return &userOut, nil
+}
+
+func (s *userUseCase) PurgeUser(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return domain.ErrBadRequest
+	}
+
+	return s.userRepo.PurgeUser(ctx, id)
+}
+
+func (s *userUseCase) SuspendUser(ctx context.Context, id uuid.UUID) (*UserOutput, error) {
+	return s.changeUserStatus(ctx, id, userdomain.StatusSuspended)
+}
+
+func (s *userUseCase) ActivateUser(ctx context.Context, id uuid.UUID) (*UserOutput, error) {
+	return s.changeUserStatus(ctx, id, userdomain.StatusActive)
+}
+
+func (s *userUseCase) LockUser(ctx context.Context, id uuid.UUID) (*UserOutput, error) {
+	return s.changeUserStatus(ctx, id, userdomain.StatusLocked)
+}
+
+// ResendInvitation mails a new link to users who still have no password of
+// their own, such as those whose invitation expired.
+func (s *userUseCase) ResendInvitation(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return domain.ErrBadRequest
+	}
+
+	user, err := s.userRepo.GetUser(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !user.MustChangePassword {
+		return domain.ErrPasswordAlreadySet
+	}
+
+	return s.inviter.ResendInvitation(ctx, user)
 }
 
 func (s *userUseCase) UpdateUser(ctx context.Context, input UpdateUserInput) (*UserOutput, error) {
@@ -123,15 +249,54 @@ func (s *userUseCase) UpdateUser(ctx context.Context, input UpdateUserInput) (*U
 	return &userOut, nil
 }
 
+func (s *userUseCase) changeUserStatus(ctx context.Context, id uuid.UUID, next userdomain.Status) (*UserOutput, error) {
+	if id == uuid.Nil {
+		return nil, domain.ErrBadRequest
+	}
+
+	user, err := s.userRepo.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := user.TransitionTo(next, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.ChangeUserStatus(ctx, change); err != nil {
+		return nil, err
+	}
+
+	updatedUser, err := s.userRepo.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	userOut := toUserOutput(updatedUser)
+	return &userOut, nil
+}
+
 func toUserOutput(user *userdomain.User) UserOutput {
 	return UserOutput{
-		ID:        user.ID,
-		Name:      user.Name,
-		LastName:  user.LastName,
-		Username:  user.Username,
-		Email:     user.Email,
-		Avatar:    user.Avatar,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:                 user.ID,
+		Name:               user.Name,
+		LastName:           user.LastName,
+		Username:           user.Username,
+		Email:              user.Email,
+		EmailVerifiedAt:    user.EmailVerifiedAt,
+		MustChangePassword: user.MustChangePassword,
+		Avatar:             user.Avatar,
+		Status:             string(user.Status),
+		CreatedAt:          user.CreatedAt,
+		UpdatedAt:          user.UpdatedAt,
 	}
 }
+
+type noopInviter struct{}
+
+func (noopInviter) InviteUser(context.Context, *userdomain.User) {}
+
+func (noopInviter) ResendInvitation(context.Context, *userdomain.User) error {
+	return nil
+}
@@ -0,0 +1,45 @@
+CREATE TABLE permissions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    CONSTRAINT permissions_name_not_blank_chk CHECK (btrim(name) <> ''),
+    CONSTRAINT permissions_name_length_chk CHECK (char_length(name) BETWEEN 3 AND 100),
+    CONSTRAINT permissions_name_format_chk CHECK (name ~ '^[a-z][a-z0-9_-]*:[a-z][a-z0-9_-]*$'),
+    CONSTRAINT permissions_description_length_chk CHECK (description IS NULL OR char_length(description) <= 255)
+);
+
+CREATE UNIQUE INDEX permissions_name_lower_uidx ON permissions (lower(name));
+
+CREATE TRIGGER permissions_set_updated_at_trg
+BEFORE UPDATE ON permissions
+FOR EACH ROW
+EXECUTE FUNCTION set_updated_at();
+
+CREATE TABLE role_permissions (
+    role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission_id UUID NOT NULL REFERENCES permissions(id) ON DELETE RESTRICT,
+    granted_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+CREATE INDEX role_permissions_permission_id_idx ON role_permissions (permission_id);
+
+INSERT INTO permissions (name, description)
+VALUES
+    ('users:read', 'List and view users'),
+    ('users:write', 'Create, update and delete users'),
+    ('roles:read', 'List and view roles'),
+    ('roles:write', 'Create, update and delete roles'),
+    ('roles:assign', 'Assign and revoke user roles')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON
+    (r.name = 'admin')
+    OR (r.name = 'manager' AND p.name IN ('users:read', 'users:write', 'roles:read'))
+    OR (r.name = 'viewer' AND p.name IN ('users:read'))
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- The expression must match the search document used by UserRepository.SearchUsers.
+CREATE INDEX users_search_trgm_idx ON users
+USING GIN ((name || ' ' || last_name || ' ' || username || ' ' || lower(email)) gin_trgm_ops);
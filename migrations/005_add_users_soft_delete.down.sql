@@ -0,0 +1,8 @@
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE name = 'users:purge');
+
+DELETE FROM permissions WHERE name = 'users:purge';
+
+DROP INDEX IF EXISTS users_deleted_at_idx;
+
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
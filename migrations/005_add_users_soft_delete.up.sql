@@ -0,0 +1,14 @@
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX users_deleted_at_idx ON users (deleted_at) WHERE deleted_at IS NOT NULL;
+
+INSERT INTO permissions (name, description)
+VALUES ('users:purge', 'Permanently remove users and their data')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.name = 'users:purge'
+WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS users_status_idx;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_chk;
+ALTER TABLE users DROP COLUMN IF EXISTS status;
@@ -0,0 +1,5 @@
+ALTER TABLE users ADD COLUMN status TEXT NOT NULL DEFAULT 'active';
+
+ALTER TABLE users ADD CONSTRAINT users_status_chk CHECK (status IN ('active', 'suspended', 'locked', 'pending'));
+
+CREATE INDEX users_status_idx ON users (status);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS auth_refresh_tokens_replaced_by_id_idx;
+
+ALTER TABLE auth_refresh_tokens DROP COLUMN IF EXISTS replaced_by_id;
@@ -0,0 +1,4 @@
+ALTER TABLE auth_refresh_tokens
+    ADD COLUMN replaced_by_id UUID REFERENCES auth_refresh_tokens(id) ON DELETE SET NULL;
+
+CREATE INDEX auth_refresh_tokens_replaced_by_id_idx ON auth_refresh_tokens (replaced_by_id) WHERE replaced_by_id IS NOT NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE auth_refresh_tokens
+    DROP COLUMN IF EXISTS device_label,
+    DROP COLUMN IF EXISTS ip_address,
+    DROP COLUMN IF EXISTS user_agent;
@@ -0,0 +1,7 @@
+ALTER TABLE auth_refresh_tokens
+    ADD COLUMN user_agent TEXT,
+    ADD COLUMN ip_address TEXT,
+    ADD COLUMN device_label TEXT,
+    ADD CONSTRAINT auth_refresh_tokens_user_agent_length_chk CHECK (user_agent IS NULL OR char_length(user_agent) <= 512),
+    ADD CONSTRAINT auth_refresh_tokens_ip_address_length_chk CHECK (ip_address IS NULL OR char_length(ip_address) <= 64),
+    ADD CONSTRAINT auth_refresh_tokens_device_label_length_chk CHECK (device_label IS NULL OR char_length(device_label) <= 100);
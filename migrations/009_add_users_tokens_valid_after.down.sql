@@ -0,0 +1,6 @@
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE name = 'users:sessions');
+
+DELETE FROM permissions WHERE name = 'users:sessions';
+
+ALTER TABLE users DROP COLUMN IF EXISTS tokens_valid_after;
@@ -0,0 +1,12 @@
+ALTER TABLE users ADD COLUMN tokens_valid_after TIMESTAMP;
+
+INSERT INTO permissions (name, description)
+VALUES ('users:sessions', 'List and revoke sessions of any user')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.name = 'users:sessions'
+WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS auth_access_token_denylist;
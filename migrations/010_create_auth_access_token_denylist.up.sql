@@ -0,0 +1,8 @@
+CREATE TABLE auth_access_token_denylist (
+    token_id TEXT PRIMARY KEY,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    CONSTRAINT auth_access_token_denylist_token_id_not_blank_chk CHECK (btrim(token_id) <> '')
+);
+
+CREATE INDEX auth_access_token_denylist_expires_at_idx ON auth_access_token_denylist (expires_at);
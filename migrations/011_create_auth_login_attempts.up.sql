@@ -0,0 +1,13 @@
+CREATE TABLE auth_login_attempts (
+    throttle_key TEXT PRIMARY KEY,
+    failures INTEGER NOT NULL DEFAULT 0,
+    window_started_at TIMESTAMP NOT NULL,
+    lockouts INTEGER NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    CONSTRAINT auth_login_attempts_throttle_key_not_blank_chk CHECK (btrim(throttle_key) <> ''),
+    CONSTRAINT auth_login_attempts_failures_chk CHECK (failures >= 0),
+    CONSTRAINT auth_login_attempts_lockouts_chk CHECK (lockouts >= 0)
+);
+
+CREATE INDEX auth_login_attempts_updated_at_idx ON auth_login_attempts (updated_at);
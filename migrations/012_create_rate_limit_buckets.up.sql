@@ -0,0 +1,9 @@
+CREATE TABLE rate_limit_buckets (
+    bucket_key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    full_at TIMESTAMP NOT NULL,
+    CONSTRAINT rate_limit_buckets_bucket_key_not_blank_chk CHECK (btrim(bucket_key) <> '')
+);
+
+CREATE INDEX rate_limit_buckets_full_at_idx ON rate_limit_buckets (full_at);
@@ -0,0 +1,21 @@
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP;
+
+-- Accounts created before verification existed keep working when
+-- AUTH_REQUIRE_VERIFIED_EMAIL is turned on.
+UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL;
+
+CREATE TABLE user_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose TEXT NOT NULL,
+    token_hash TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    CONSTRAINT user_tokens_token_hash_not_blank_chk CHECK (btrim(token_hash) <> ''),
+    CONSTRAINT user_tokens_purpose_not_blank_chk CHECK (btrim(purpose) <> '')
+);
+
+CREATE UNIQUE INDEX user_tokens_token_hash_uidx ON user_tokens (token_hash);
+CREATE INDEX user_tokens_user_id_purpose_idx ON user_tokens (user_id, purpose);
+CREATE INDEX user_tokens_expires_at_idx ON user_tokens (expires_at);
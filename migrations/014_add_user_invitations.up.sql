@@ -0,0 +1,9 @@
+ALTER TABLE users ADD COLUMN must_change_password BOOLEAN NOT NULL DEFAULT false;
+
+-- Accounts created by an administrator used their username as password. The
+-- ones that never signed in must choose a new password at their first login.
+UPDATE users u
+SET must_change_password = true
+WHERE NOT EXISTS (
+    SELECT 1 FROM auth_refresh_tokens art WHERE art.user_id = u.id
+);
@@ -0,0 +1,19 @@
+CREATE TABLE user_totp_credentials (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret_ciphertext TEXT NOT NULL,
+    confirmed_at TIMESTAMP,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    CONSTRAINT user_totp_credentials_secret_not_blank_chk CHECK (btrim(secret_ciphertext) <> '')
+);
+
+CREATE TABLE user_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash TEXT NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    CONSTRAINT user_recovery_codes_code_hash_not_blank_chk CHECK (btrim(code_hash) <> '')
+);
+
+CREATE UNIQUE INDEX user_recovery_codes_user_id_code_hash_uidx ON user_recovery_codes (user_id, code_hash);
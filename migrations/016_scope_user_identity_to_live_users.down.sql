@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS users_email_lower_active_uidx;
+DROP INDEX IF EXISTS users_username_active_uidx;
+
+-- Fails while a soft deleted user shares its username or email with another
+-- user; purge one of them first.
+CREATE UNIQUE INDEX users_email_lower_uidx ON users (lower(email));
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
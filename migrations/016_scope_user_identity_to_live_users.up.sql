@@ -0,0 +1,7 @@
+-- Soft deleted users keep their rows until purged; they must not hold on to
+-- their username and email.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_key;
+DROP INDEX IF EXISTS users_email_lower_uidx;
+
+CREATE UNIQUE INDEX users_username_active_uidx ON users (username) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX users_email_lower_active_uidx ON users (lower(email)) WHERE deleted_at IS NULL;
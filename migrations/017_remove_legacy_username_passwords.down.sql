@@ -0,0 +1,3 @@
+-- The removed passwords cannot be restored; affected users set a new one
+-- through an invitation or a password reset.
+SELECT 1;
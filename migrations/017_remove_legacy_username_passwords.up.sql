@@ -0,0 +1,20 @@
+-- Migration 014 flagged the accounts that never signed in. Those still using
+-- their username as password lose it: it is guessable, and login no longer
+-- offers a forced password change. Administrators send them a new invitation
+-- through POST /users/{id}/invitation; the forgot password flow works too.
+-- Only flagged rows are hashed, and the CASE keeps crypt() away from hashes
+-- that are not bcrypt.
+UPDATE users
+SET password_hash = '!'
+WHERE must_change_password
+  AND CASE
+      WHEN password_hash LIKE '$2%' THEN crypt(username, password_hash) = password_hash
+      ELSE false
+  END;
+
+-- The other flagged accounts chose their own password through a reset link
+-- and have nothing left to change.
+UPDATE users
+SET must_change_password = false
+WHERE must_change_password
+  AND password_hash <> '!';
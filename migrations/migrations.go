@@ -0,0 +1,8 @@
+package migrations
+
+import "embed"
+
+// FS holds the versioned SQL files as <version>_<name>.up.sql / .down.sql pairs.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,58 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+)
+
+var ErrInvalidCiphertext = errors.New("invalid ciphertext")
+
+// AESGCM encrypts small secrets at rest. Every value gets its own random
+// nonce, stored in front of the ciphertext.
+type AESGCM struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCM takes a 16, 24 or 32 byte key.
+func NewAESGCM(key []byte) (*AESGCM, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AESGCM{aead: aead}, nil
+}
+
+func (c *AESGCM) Seal(plaintext []byte) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, plaintext, nil)
+	return base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *AESGCM) Open(ciphertext string) ([]byte, error) {
+	sealed, err := base64.RawStdEncoding.DecodeString(ciphertext)
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+
+	nonce, body := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	plaintext, err := c.aead.Open(nil, nonce, body, nil)
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+
+	return plaintext, nil
+}
@@ -4,6 +4,10 @@ const (
 	MsgInvalidConfiguration     = "invalid configuration"
 	MsgDatabaseInitFailed       = "database initialization failed"
 	MsgDatabaseCloseFailed      = "database close failed"
+	MsgMigrationFailed          = "migration failed"
+	MsgMigrationApplied         = "migration applied"
+	MsgMigrationRolledBack      = "migration rolled back"
+	MsgMigrationsUpToDate       = "migrations up to date"
 	MsgServerStarted            = "server started"
 	MsgServerFailed             = "server failed"
 	MsgHTTPRequest              = "http_request"
@@ -13,4 +17,10 @@ const (
 	MsgResponseFallbackWriteErr = "response fallback write error"
 	MsgUserRequestFailed        = "user_request_failed"
 	MsgAuthRequestFailed        = "auth_request_failed"
+	MsgRoleRequestFailed        = "role_request_failed"
+	MsgSecurityEvent            = "security_event"
+	MsgAuthenticationFailed     = "authentication_failed"
+	MsgRateLimitFailed          = "rate_limit_failed"
+	MsgMailSent                 = "mail_sent"
+	MsgJWTKeyRetired            = "jwt_key_retired"
 )
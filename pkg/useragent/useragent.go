@@ -0,0 +1,82 @@
+package useragent
+
+import "strings"
+
+const unknown = "Unknown"
+
+type Info struct {
+	Browser string
+	OS      string
+}
+
+type marker struct {
+	token string
+	name  string
+}
+
+// Order matters: most browsers also claim to be Safari or Chrome, so the more
+// specific tokens have to be checked first.
+var browserMarkers = []marker{
+	{token: "edg/", name: "Edge"},
+	{token: "edga/", name: "Edge"},
+	{token: "edgios/", name: "Edge"},
+	{token: "opr/", name: "Opera"},
+	{token: "samsungbrowser/", name: "Samsung Internet"},
+	{token: "firefox/", name: "Firefox"},
+	{token: "fxios/", name: "Firefox"},
+	{token: "crios/", name: "Chrome"},
+	{token: "chrome/", name: "Chrome"},
+	{token: "safari/", name: "Safari"},
+	{token: "curl/", name: "curl"},
+	{token: "postmanruntime/", name: "Postman"},
+	{token: "go-http-client/", name: "Go HTTP client"},
+}
+
+var osMarkers = []marker{
+	{token: "android", name: "Android"},
+	{token: "iphone", name: "iOS"},
+	{token: "ipad", name: "iPadOS"},
+	{token: "cros ", name: "ChromeOS"},
+	{token: "mac os x", name: "macOS"},
+	{token: "macintosh", name: "macOS"},
+	{token: "windows", name: "Windows"},
+	{token: "linux", name: "Linux"},
+}
+
+func Parse(userAgent string) Info {
+	normalized := strings.ToLower(strings.TrimSpace(userAgent))
+
+	return Info{
+		Browser: firstMatch(normalized, browserMarkers),
+		OS:      firstMatch(normalized, osMarkers),
+	}
+}
+
+// Label renders a short device description such as "Chrome on macOS".
+func Label(userAgent string) string {
+	if strings.TrimSpace(userAgent) == "" {
+		return ""
+	}
+
+	info := Parse(userAgent)
+	switch {
+	case info.Browser == unknown && info.OS == unknown:
+		return unknown
+	case info.OS == unknown:
+		return info.Browser
+	case info.Browser == unknown:
+		return info.OS
+	default:
+		return info.Browser + " on " + info.OS
+	}
+}
+
+func firstMatch(userAgent string, markers []marker) string {
+	for _, m := range markers {
+		if strings.Contains(userAgent, m.token) {
+			return m.name
+		}
+	}
+
+	return unknown
+}
@@ -33,7 +33,7 @@ require_cmd() {
 api_ready() {
   local code
   code="$(curl -s -o /dev/null -w '%{http_code}' "${API_URL}/users" || true)"
-  [[ "$code" == "200" ]]
+  [[ "$code" == "200" || "$code" == "401" ]]
 }
 
 start_stack_if_needed() {
@@ -224,7 +224,7 @@ cleanup_user_if_exists() {
 }
 
 run_tests() {
-  local suffix username email password user_id access_token new_access_token latest_access_token
+  local suffix username email password user_id access_token new_access_token latest_access_token change_access_token
   local access_exp_epoch refresh_exp_epoch old_refresh_token rotated_refresh_token
   suffix="$(date +%s)$RANDOM"
   username="auth_${suffix}"
@@ -339,9 +339,16 @@ run_tests() {
   log "T20: POST /auth/refresh reuse old refresh token"
   request_with_cookie_value "POST" "/auth/refresh" "$REFRESH_COOKIE_NAME" "$old_refresh_token"
   assert_status "401" "T20"
-  assert_jq '.success == false and .code == "UNAUTHORIZED"' "T20"
+  assert_jq '.success == false and .code == "REFRESH_TOKEN_REUSED"' "T20"
 
-  latest_access_token="$new_access_token"
+  log "T20b: POST /auth/refresh with rotated token after reuse (family revoked)"
+  request "POST" "/auth/refresh" "" "" "" "1"
+  assert_status "401" "T20b"
+  assert_jq '.success == false and .code == "UNAUTHORIZED"' "T20b"
+
+  request "POST" "/auth/login" "{\"identity\":\"${email}\",\"password\":\"${password}\"}" "application/json" "" "1"
+  assert_status "200" "T20b-login"
+  latest_access_token="$(jq -r '.data.accessToken' <<<"$RESPONSE_BODY")"
 
   if [[ "$RUN_EXPIRY_TESTS" == "1" ]]; then
     log "T21: access token expiration behavior"
@@ -377,10 +384,19 @@ run_tests() {
     fi
   fi
 
+  request "POST" "/auth/login" "{\"identity\":\"${email}\",\"password\":\"${password}\"}" "application/json" "" "1"
+  assert_status "200" "T23-login"
+  logout_access_token="$(jq -r '.data.accessToken' <<<"$RESPONSE_BODY")"
+
   log "T23: POST /auth/logout"
-  request "POST" "/auth/logout" "" "" "" "1"
+  request "POST" "/auth/logout" "" "" "Bearer ${logout_access_token}" "1"
   assert_status "204" "T23"
 
+  log "T23b: GET /auth/me with access token revoked by logout"
+  request "GET" "/auth/me" "" "" "Bearer ${logout_access_token}" "0"
+  assert_status "401" "T23b"
+  assert_jq '.success == false and .code == "UNAUTHORIZED"' "T23b"
+
   log "T24: POST /auth/logout without cookie (idempotent)"
   request "POST" "/auth/logout" "" "" "" "0"
   assert_status "204" "T24"
@@ -390,6 +406,72 @@ run_tests() {
   assert_status "401" "T25"
   assert_jq '.success == false and .code == "UNAUTHORIZED"' "T25"
 
+  log "T26: POST /auth/verify-email with unknown token"
+  request "POST" "/auth/verify-email" "{\"token\":\"not-a-real-token\"}"
+  assert_status "400" "T26"
+  assert_jq '.success == false and .code == "INVALID_TOKEN"' "T26"
+
+  log "T27: POST /auth/resend-verification does not reveal accounts"
+  request "POST" "/auth/resend-verification" "{\"email\":\"${email}\"}"
+  assert_status "202" "T27"
+  request "POST" "/auth/resend-verification" "{\"email\":\"missing_${email}\"}"
+  assert_status "202" "T27"
+
+  log "T28: POST /auth/password/forgot does not reveal accounts"
+  request "POST" "/auth/password/forgot" "{\"email\":\"${email}\"}"
+  assert_status "202" "T28"
+  request "POST" "/auth/password/forgot" "{\"email\":\"missing_${email}\"}"
+  assert_status "202" "T28"
+
+  log "T29: POST /auth/password/reset with unknown token"
+  request "POST" "/auth/password/reset" "{\"token\":\"not-a-real-token\",\"password\":\"Another-Str0ng-Pass!\"}"
+  assert_status "400" "T29"
+  assert_jq '.success == false and .code == "INVALID_TOKEN"' "T29"
+
+  request "POST" "/auth/login" "{\"identity\":\"${email}\",\"password\":\"${password}\"}" "application/json" "" "1"
+  assert_status "200" "T30-login"
+  change_access_token="$(jq -r '.data.accessToken' <<<"$RESPONSE_BODY")"
+
+  log "T30: POST /auth/password/change with wrong current password"
+  request "POST" "/auth/password/change" "{\"currentPassword\":\"Wrong-Pass-123\",\"newPassword\":\"Another-Str0ng-Pass!\"}" "application/json" "Bearer ${change_access_token}" "1"
+  assert_status "400" "T30"
+  assert_jq '.success == false and .code == "INVALID_CURRENT_PASSWORD"' "T30"
+
+  log "T31: POST /auth/password/change rejects the current password"
+  request "POST" "/auth/password/change" "{\"currentPassword\":\"${password}\",\"newPassword\":\"${password}\"}" "application/json" "Bearer ${change_access_token}" "1"
+  assert_status "400" "T31"
+  assert_jq '.success == false and .code == "PASSWORD_REUSED"' "T31"
+
+  log "T32: POST /auth/password/change keeps the calling session"
+  request "POST" "/auth/password/change" "{\"currentPassword\":\"${password}\",\"newPassword\":\"Another-Str0ng-Pass!\",\"revokeOtherSessions\":true}" "application/json" "Bearer ${change_access_token}" "1"
+  assert_status "204" "T32"
+  request "POST" "/auth/refresh" "" "" "" "1"
+  assert_status "200" "T32-refresh"
+  mfa_access_token="$(jq -r '.data.accessToken' <<<"$RESPONSE_BODY")"
+
+  log "T33: POST /auth/invitation/accept with unknown token"
+  request "POST" "/auth/invitation/accept" "{\"token\":\"not-a-real-token\",\"password\":\"Another-Str0ng-Pass!\"}"
+  assert_status "400" "T33"
+  assert_jq '.success == false and .code == "INVALID_TOKEN"' "T33"
+
+  log "T34: POST /auth/mfa/challenge with unknown token"
+  request "POST" "/auth/mfa/challenge" "{\"challengeToken\":\"not-a-real-token\",\"code\":\"123456\"}"
+  assert_status "400" "T34"
+  assert_jq '.success == false and .code == "INVALID_TOKEN"' "T34"
+
+  log "T35: POST /auth/mfa/totp/setup requires the current password"
+  request "POST" "/auth/mfa/totp/setup" "{\"currentPassword\":\"Wrong-Pass-123\"}" "application/json" "Bearer ${mfa_access_token}"
+  assert_status "400" "T35"
+  assert_jq '.success == false and .code == "INVALID_CURRENT_PASSWORD"' "T35"
+  request "POST" "/auth/mfa/totp/setup" "{\"currentPassword\":\"Another-Str0ng-Pass!\"}" "application/json" "Bearer ${mfa_access_token}"
+  assert_status "200" "T35"
+  assert_jq '.success == true and (.data.secret | length) > 0 and (.data.otpauthUri | startswith("otpauth://totp/"))' "T35"
+
+  log "T36: POST /auth/mfa/totp/verify rejects a wrong code"
+  request "POST" "/auth/mfa/totp/verify" "{\"code\":\"abcdef\"}" "application/json" "Bearer ${mfa_access_token}"
+  assert_status "400" "T36"
+  assert_jq '.success == false and .code == "INVALID_MFA_CODE"' "T36"
+
   cleanup_user_if_exists "$user_id"
   log "All auth endpoint tests passed."
 }
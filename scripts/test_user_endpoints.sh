@@ -6,6 +6,7 @@ API_URL="${API_URL:-http://localhost:9090}"
 AUTO_START="${AUTO_START:-1}"
 RESET_DB="${RESET_DB:-0}"
 WAIT_SECONDS="${WAIT_SECONDS:-90}"
+ACCESS_TOKEN="${ACCESS_TOKEN:-}"
 
 log() {
   printf '[e2e] %s\n' "$*"
@@ -23,7 +24,7 @@ require_cmd() {
 api_ready() {
   local code
   code="$(curl -s -o /dev/null -w '%{http_code}' "${API_URL}/users" || true)"
-  [[ "$code" == "200" ]]
+  [[ "$code" == "200" || "$code" == "401" ]]
 }
 
 start_stack_if_needed() {
@@ -72,13 +73,19 @@ request() {
   local tmp status
   tmp="$(mktemp)"
 
+  local curl_args
+  curl_args=(-sS -o "$tmp" -w '%{http_code}' -X "$method")
+  if [[ -n "$ACCESS_TOKEN" ]]; then
+    curl_args+=(-H "Authorization: Bearer ${ACCESS_TOKEN}")
+  fi
+
   if [[ -n "$payload" ]]; then
-    status="$(curl -sS -o "$tmp" -w '%{http_code}' -X "$method" \
+    status="$(curl "${curl_args[@]}" \
       -H "Content-Type: ${content_type}" \
       --data "$payload" \
       "${API_URL}${path}" || true)"
   else
-    status="$(curl -sS -o "$tmp" -w '%{http_code}' -X "$method" \
+    status="$(curl "${curl_args[@]}" \
       "${API_URL}${path}" || true)"
   fi
 
@@ -96,7 +103,13 @@ request_file() {
   local tmp status
   tmp="$(mktemp)"
 
-  status="$(curl -sS -o "$tmp" -w '%{http_code}' -X "$method" \
+  local curl_args
+  curl_args=(-sS -o "$tmp" -w '%{http_code}' -X "$method")
+  if [[ -n "$ACCESS_TOKEN" ]]; then
+    curl_args+=(-H "Authorization: Bearer ${ACCESS_TOKEN}")
+  fi
+
+  status="$(curl "${curl_args[@]}" \
     -H "Content-Type: ${content_type}" \
     --data-binary "@${file}" \
     "${API_URL}${path}" || true)"
@@ -120,6 +133,26 @@ random_uuid() {
   echo "00000000-0000-0000-0000-000000000123"
 }
 
+ensure_access_token() {
+  if [[ -n "$ACCESS_TOKEN" ]]; then
+    return
+  fi
+
+  local suffix username email password
+  suffix="$(date +%s)$RANDOM"
+  username="e2e_${suffix}"
+  email="e2e_${suffix}@example.com"
+  password="StrongP@ss1"
+
+  request "POST" "/auth/register" "{\"name\":\"E2E\",\"lastName\":\"Runner\",\"username\":\"${username}\",\"email\":\"${email}\",\"password\":\"${password}\",\"avatar\":\"\"}"
+  [[ "$RESPONSE_STATUS" == "201" ]] || fail "register e2e user failed: http=${RESPONSE_STATUS} body=${RESPONSE_BODY}"
+
+  request "POST" "/auth/login" "{\"identity\":\"${email}\",\"password\":\"${password}\"}"
+  [[ "$RESPONSE_STATUS" == "200" ]] || fail "login e2e user failed: http=${RESPONSE_STATUS} body=${RESPONSE_BODY}"
+
+  ACCESS_TOKEN="$(jq -r '.data.accessToken' <<<"$RESPONSE_BODY")"
+}
+
 delete_user_if_exists() {
   local id="$1"
   request "DELETE" "/users/${id}"
@@ -155,6 +188,14 @@ run_tests() {
   updated_email="upd_${email}"
   upper_email="$(tr '[:lower:]' '[:upper:]' <<<"$updated_email")"
 
+  log "T0: GET /users without access token"
+  local access_token="$ACCESS_TOKEN"
+  ACCESS_TOKEN=""
+  request "GET" "/users"
+  ACCESS_TOKEN="$access_token"
+  assert_status "401" "T0"
+  assert_jq '.success == false and .code == "UNAUTHORIZED"' "T0"
+
   log "T1: GET /users"
   request "GET" "/users"
   assert_status "200" "T1"
@@ -170,6 +211,7 @@ run_tests() {
   assert_status "201" "T3"
   assert_jq '.success == true and (.data.id | length > 0)' "T3"
   assert_jq '.data.username == "'"${username}"'" and .data.email == "'"${email}"'"' "T3"
+  assert_jq '.data.mustChangePassword == true' "T3"
   user_id="$(jq -r '.data.id' <<<"$RESPONSE_BODY")"
   if [[ -z "$user_id" || "$user_id" == "null" ]]; then
     fail "T3: invalid user id extracted"
@@ -180,15 +222,24 @@ run_tests() {
   assert_status "200" "T4"
   assert_jq '.success == true and .data.id == "'"${user_id}"'"' "T4"
 
+  log "T4b: POST /users/{id}/invitation"
+  request "POST" "/users/${user_id}/invitation"
+  assert_status "204" "T4b"
+
   log "T5: PUT /users/{id}"
   request "PUT" "/users/${user_id}" "{\"name\":\"Admin2\",\"lastName\":\"User2\",\"username\":\"${username_upd}\",\"email\":\"${updated_email}\",\"avatar\":\"https://example.com/b.png\"}"
   assert_status "200" "T5"
   assert_jq '.success == true and .data.username == "'"${username_upd}"'"' "T5"
 
   log "T6: GET /users list contains user"
-  request "GET" "/users"
+  request "GET" "/users?username=${username_upd}&limit=5"
   assert_status "200" "T6"
-  assert_jq '.success == true and ([.data[].id] | index("'"${user_id}"'") != null)' "T6"
+  assert_jq '.success == true and ([.data[].id] | index("'"${user_id}"'") != null) and .meta.total >= 1 and .meta.limit == 5' "T6"
+
+  log "T6b: GET /users invalid sort"
+  request "GET" "/users?sort=password_hash"
+  assert_status "400" "T6b"
+  assert_jq '.success == false and .code == "INVALID_QUERY"' "T6b"
 
   log "T7: POST duplicate email (case-insensitive)"
   request "POST" "/users" "{\"name\":\"Dup\",\"lastName\":\"Mail\",\"username\":\"${username}_dup\",\"email\":\"${upper_email}\",\"avatar\":\"\"}"
@@ -284,6 +335,7 @@ main() {
 
   start_stack_if_needed
   wait_api
+  ensure_access_token
   run_tests
 }
 